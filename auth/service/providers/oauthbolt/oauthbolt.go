@@ -11,7 +11,7 @@ import (
 	"github.com/influx6/faux/auth"
 	"github.com/influx6/faux/auth/service"
 	"github.com/influx6/faux/crypt"
-	uuid "github.com/satori/go.uuid"
+	"github.com/influx6/faux/uuid"
 )
 
 var (
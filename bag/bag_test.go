@@ -1,7 +1,9 @@
 package bag_test
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/influx6/faux/bag"
 	"github.com/influx6/faux/tests"
@@ -24,3 +26,80 @@ func TestValueBag(t *testing.T) {
 	}
 	tests.Passed("Should match expected value")
 }
+
+func TestBagSnapshot(t *testing.T) {
+	b := bag.New(bag.Fields{"name": "bob", "timeout": "2s"})
+
+	snapshot := b.Snapshot()
+	b.Set("name", "alice")
+	b.Delete("timeout")
+
+	if val, _ := snapshot.GetString("name"); val != "bob" {
+		tests.Failed("Should not change snapshot after write")
+	}
+	tests.Passed("Should not change snapshot after write")
+
+	if dur, ok := snapshot.GetDuration("timeout"); !ok || dur != 2*time.Second {
+		tests.Failed("Should parse duration from snapshot")
+	}
+	tests.Passed("Should parse duration from snapshot")
+
+	if val, _ := b.GetString("name"); val != "alice" {
+		tests.Failed("Should match updated value")
+	}
+	tests.Passed("Should match updated value")
+
+	if _, ok := b.Get("timeout"); ok {
+		tests.Failed("Should have deleted key")
+	}
+	tests.Passed("Should have deleted key")
+
+	var vb bag.ValueBag = b
+	vb = vb.WithValue("age", 20)
+
+	if age, _ := vb.GetInt("age"); age != 20 || b.Len() != 1 {
+		tests.Failed("Should return new bag with value")
+	}
+	tests.Passed("Should return new bag with value")
+}
+
+func TestBagMerge(t *testing.T) {
+	b := bag.New(bag.Fields{"name": "bob"})
+
+	b.Merge(bag.Fields{"name": "alice", "role": "admin"}, bag.KeepExisting)
+	if val, _ := b.GetString("name"); val != "bob" {
+		tests.Failed("Should keep existing value on merge")
+	}
+	tests.Passed("Should keep existing value on merge")
+
+	if val, _ := b.GetString("role"); val != "admin" {
+		tests.Failed("Should add missing key on merge")
+	}
+	tests.Passed("Should add missing key on merge")
+
+	b.Merge(bag.Fields{"name": "alice"}, bag.Overwrite)
+	if val, _ := b.GetString("name"); val != "alice" {
+		tests.Failed("Should overwrite value on merge")
+	}
+	tests.Passed("Should overwrite value on merge")
+}
+
+func TestBagConcurrency(t *testing.T) {
+	b := bag.New(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b.Set(i, i)
+			b.GetInt(i)
+		}(i)
+	}
+	wg.Wait()
+
+	if b.Len() != 10 {
+		tests.Failed("Should hold all concurrently set keys")
+	}
+	tests.Passed("Should hold all concurrently set keys")
+}
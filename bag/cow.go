@@ -0,0 +1,254 @@
+package bag
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MergeMode defines how conflicting keys are resolved when merging fields.
+type MergeMode int
+
+// merge modes.
+const (
+	// Overwrite replaces existing values with merged ones.
+	Overwrite MergeMode = iota
+
+	// KeepExisting keeps existing values, only adding missing keys.
+	KeepExisting
+)
+
+// Bag defines a concurrency-safe key-value bag which uses copy-on-write, so
+// reads never lock and snapshots are free. Every write copies the fields,
+// making it best suited for data read far more often than written, such as
+// request-scoped metadata.
+type Bag struct {
+	ml     sync.Mutex
+	fields atomic.Value
+}
+
+// New returns a new instance of a Bag holding a copy of the giving fields.
+func New(fields Fields) *Bag {
+	var b Bag
+	b.fields.Store(fields.Copy())
+	return &b
+}
+
+// Snapshot returns the current fields of the bag, which must not be modified.
+// Later writes to the bag do not affect the returned fields.
+func (b *Bag) Snapshot() Fields {
+	if fields, ok := b.fields.Load().(Fields); ok {
+		return fields
+	}
+	return Fields{}
+}
+
+// Len returns the total of fields in the bag.
+func (b *Bag) Len() int {
+	return len(b.Snapshot())
+}
+
+// Set adds a key-value pair into the bag.
+func (b *Bag) Set(key, value interface{}) {
+	b.update(func(fields Fields) {
+		fields[key] = value
+	})
+}
+
+// Delete removes the key from the bag.
+func (b *Bag) Delete(key interface{}) {
+	b.update(func(fields Fields) {
+		delete(fields, key)
+	})
+}
+
+// Merge adds all giving fields into the bag, resolving conflicting keys with
+// the giving mode.
+func (b *Bag) Merge(other Fields, mode MergeMode) {
+	b.update(func(fields Fields) {
+		fields.merge(other, mode)
+	})
+}
+
+// With returns a new Bag holding the fields of this bag and the giving
+// key-value pair, leaving this bag unchanged.
+func (b *Bag) With(key, value interface{}) *Bag {
+	fields := b.Snapshot().Copy()
+	fields[key] = value
+
+	var nb Bag
+	nb.fields.Store(fields)
+	return &nb
+}
+
+// WithValue returns a new ValueBag holding the fields of this bag and the
+// giving key-value pair, allowing a Bag to be used as a ValueBag.
+func (b *Bag) WithValue(key, value interface{}) ValueBag {
+	return b.With(key, value)
+}
+
+// update applies fn to a copy of the current fields and stores the copy.
+func (b *Bag) update(fn func(Fields)) {
+	b.ml.Lock()
+	defer b.ml.Unlock()
+
+	fields := b.Snapshot().Copy()
+	fn(fields)
+	b.fields.Store(fields)
+}
+
+// Get returns the value of a key if it exists.
+func (b *Bag) Get(key interface{}) (interface{}, bool) { return b.Snapshot().Get(key) }
+
+// GetInt returns the int value of a key if it exists.
+func (b *Bag) GetInt(key interface{}) (int, bool) { return b.Snapshot().GetInt(key) }
+
+// GetBool returns the bool value of a key if it exists.
+func (b *Bag) GetBool(key interface{}) (bool, bool) { return b.Snapshot().GetBool(key) }
+
+// GetInt8 returns the int8 value of a key if it exists.
+func (b *Bag) GetInt8(key interface{}) (int8, bool) { return b.Snapshot().GetInt8(key) }
+
+// GetInt16 returns the int16 value of a key if it exists.
+func (b *Bag) GetInt16(key interface{}) (int16, bool) { return b.Snapshot().GetInt16(key) }
+
+// GetInt32 returns the int32 value of a key if it exists.
+func (b *Bag) GetInt32(key interface{}) (int32, bool) { return b.Snapshot().GetInt32(key) }
+
+// GetInt64 returns the int64 value of a key if it exists.
+func (b *Bag) GetInt64(key interface{}) (int64, bool) { return b.Snapshot().GetInt64(key) }
+
+// GetString returns the string value of a key if it exists.
+func (b *Bag) GetString(key interface{}) (string, bool) { return b.Snapshot().GetString(key) }
+
+// GetFloat32 returns the float32 value of a key if it exists.
+func (b *Bag) GetFloat32(key interface{}) (float32, bool) { return b.Snapshot().GetFloat32(key) }
+
+// GetFloat64 returns the float64 value of a key if it exists.
+func (b *Bag) GetFloat64(key interface{}) (float64, bool) { return b.Snapshot().GetFloat64(key) }
+
+// GetDuration returns the duration value of a key if it exists.
+func (b *Bag) GetDuration(key interface{}) (time.Duration, bool) {
+	return b.Snapshot().GetDuration(key)
+}
+
+// GetTime returns the time value of a key if it exists.
+func (b *Bag) GetTime(key interface{}) (time.Time, bool) { return b.Snapshot().GetTime(key) }
+
+//==============================================================================
+
+// Copy returns a copy of the fields.
+func (f Fields) Copy() Fields {
+	fields := make(Fields, len(f))
+	for key, value := range f {
+		fields[key] = value
+	}
+	return fields
+}
+
+// Merge returns a new Fields holding these fields and the giving ones,
+// resolving conflicting keys with the giving mode.
+func (f Fields) Merge(other Fields, mode MergeMode) Fields {
+	fields := f.Copy()
+	fields.merge(other, mode)
+	return fields
+}
+
+func (f Fields) merge(other Fields, mode MergeMode) {
+	for key, value := range other {
+		if _, ok := f[key]; ok && mode == KeepExisting {
+			continue
+		}
+		f[key] = value
+	}
+}
+
+// Get returns the value of a key if it exists.
+func (f Fields) Get(key interface{}) (interface{}, bool) {
+	value, ok := f[key]
+	return value, ok
+}
+
+// GetDuration returns the duration value of a key if it exists, converting
+// int64 values and parsing duration strings.
+func (f Fields) GetDuration(key interface{}) (time.Duration, bool) {
+	switch value := f[key].(type) {
+	case time.Duration:
+		return value, true
+	case int64:
+		return time.Duration(value), true
+	case string:
+		if dur, err := time.ParseDuration(value); err == nil {
+			return dur, true
+		}
+	}
+	return 0, false
+}
+
+// GetTime returns the time value of a key if it exists, parsing RFC3339
+// strings.
+func (f Fields) GetTime(key interface{}) (time.Time, bool) {
+	switch value := f[key].(type) {
+	case time.Time:
+		return value, true
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// GetBool returns the bool value of a key if it exists.
+func (f Fields) GetBool(key interface{}) (bool, bool) {
+	value, ok := f[key].(bool)
+	return value, ok
+}
+
+// GetFloat64 returns the float64 value of a key if it exists.
+func (f Fields) GetFloat64(key interface{}) (float64, bool) {
+	value, ok := f[key].(float64)
+	return value, ok
+}
+
+// GetFloat32 returns the float32 value of a key if it exists.
+func (f Fields) GetFloat32(key interface{}) (float32, bool) {
+	value, ok := f[key].(float32)
+	return value, ok
+}
+
+// GetInt8 returns the int8 value of a key if it exists.
+func (f Fields) GetInt8(key interface{}) (int8, bool) {
+	value, ok := f[key].(int8)
+	return value, ok
+}
+
+// GetInt16 returns the int16 value of a key if it exists.
+func (f Fields) GetInt16(key interface{}) (int16, bool) {
+	value, ok := f[key].(int16)
+	return value, ok
+}
+
+// GetInt32 returns the int32 value of a key if it exists.
+func (f Fields) GetInt32(key interface{}) (int32, bool) {
+	value, ok := f[key].(int32)
+	return value, ok
+}
+
+// GetInt64 returns the int64 value of a key if it exists.
+func (f Fields) GetInt64(key interface{}) (int64, bool) {
+	value, ok := f[key].(int64)
+	return value, ok
+}
+
+// GetInt returns the int value of a key if it exists.
+func (f Fields) GetInt(key interface{}) (int, bool) {
+	value, ok := f[key].(int)
+	return value, ok
+}
+
+// GetString returns the string value of a key if it exists.
+func (f Fields) GetString(key interface{}) (string, bool) {
+	value, ok := f[key].(string)
+	return value, ok
+}
@@ -0,0 +1,194 @@
+// Package batch provides a Batcher which collects items and flushes them to
+// a callback once a size or byte budget is reached or an interval passes,
+// retrying failed flushes and draining pending items on close.
+package batch
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// errors.
+var (
+	ErrClosed = errors.New("batcher already closed")
+)
+
+// FlushFunc defines a function type which receives a batch of items.
+type FlushFunc func([]interface{}) error
+
+// Config defines the configuration for a Batcher. At least one of MaxItems,
+// MaxBytes or Interval should be set, else items are only flushed by Flush
+// and Close.
+type Config struct {
+	// MaxItems flushes once the giving number of items are pending.
+	MaxItems int
+
+	// MaxBytes flushes once the total size of pending items reaches the
+	// giving budget, as measured by Sizer.
+	MaxBytes int
+
+	// Sizer returns the size of an item in bytes, required by MaxBytes.
+	Sizer func(interface{}) int
+
+	// Interval flushes pending items periodically.
+	Interval time.Duration
+
+	// Retries sets the number of times a failed flush is retried.
+	Retries int
+
+	// RetryDelay sets the delay before the first retry, doubling with each
+	// attempt.
+	RetryDelay time.Duration
+
+	// OnError is called with the batch and error of a flush which failed
+	// after all retries.
+	OnError func([]interface{}, error)
+}
+
+// Batcher collects items into batches. It is safe for concurrent use, and
+// batches are flushed one at a time in the order items were added.
+type Batcher struct {
+	config Config
+	flush  FlushFunc
+
+	fml   sync.Mutex
+	ml    sync.Mutex
+	items []interface{}
+	size  int
+
+	closed bool
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New returns a new instance of a Batcher which delivers batches to flush.
+func New(config Config, flush FlushFunc) *Batcher {
+	b := &Batcher{
+		config: config,
+		flush:  flush,
+		stop:   make(chan struct{}),
+	}
+
+	if config.Interval > 0 {
+		b.wg.Add(1)
+		go b.tick()
+	}
+
+	return b
+}
+
+// Add appends the item into the pending batch, flushing it if the batch
+// reached it's size or byte budget. It returns the error of that flush.
+func (b *Batcher) Add(item interface{}) error {
+	b.ml.Lock()
+	if b.closed {
+		b.ml.Unlock()
+		return ErrClosed
+	}
+
+	b.items = append(b.items, item)
+	if b.config.MaxBytes > 0 && b.config.Sizer != nil {
+		b.size += b.config.Sizer(item)
+	}
+
+	full := b.full()
+	b.ml.Unlock()
+
+	if !full {
+		return nil
+	}
+
+	return b.Flush()
+}
+
+// Pending returns the total of items waiting to be flushed.
+func (b *Batcher) Pending() int {
+	b.ml.Lock()
+	defer b.ml.Unlock()
+	return len(b.items)
+}
+
+// Flush delivers all pending items now, returning the error of the flush.
+func (b *Batcher) Flush() error {
+	b.fml.Lock()
+	defer b.fml.Unlock()
+
+	b.ml.Lock()
+	items := b.items
+	b.items = nil
+	b.size = 0
+	b.ml.Unlock()
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	return b.deliver(items)
+}
+
+// Close stops the batcher and flushes all pending items, returning the error
+// of that flush. Calls to Add after Close return ErrClosed.
+func (b *Batcher) Close() error {
+	b.ml.Lock()
+	if b.closed {
+		b.ml.Unlock()
+		return ErrClosed
+	}
+	b.closed = true
+	b.ml.Unlock()
+
+	close(b.stop)
+	b.wg.Wait()
+
+	return b.Flush()
+}
+
+// full returns true if the pending batch reached a limit. The caller must
+// hold b.ml.
+func (b *Batcher) full() bool {
+	if b.config.MaxItems > 0 && len(b.items) >= b.config.MaxItems {
+		return true
+	}
+	return b.config.MaxBytes > 0 && b.size >= b.config.MaxBytes
+}
+
+// deliver calls the flush function, retrying it on failure.
+func (b *Batcher) deliver(items []interface{}) error {
+	delay := b.config.RetryDelay
+
+	err := b.flush(items)
+	for attempt := 0; err != nil && attempt < b.config.Retries; attempt++ {
+		select {
+		case <-time.After(delay):
+		case <-b.stop:
+			// closing, retry without waiting.
+		}
+
+		delay *= 2
+		err = b.flush(items)
+	}
+
+	if err != nil && b.config.OnError != nil {
+		b.config.OnError(items, err)
+	}
+
+	return err
+}
+
+// tick flushes pending items on every interval until stopped.
+func (b *Batcher) tick() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.Flush()
+		case <-b.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package batch_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/batch"
+)
+
+// recorder collects flushed batches.
+type recorder struct {
+	ml      sync.Mutex
+	batches [][]interface{}
+}
+
+func (r *recorder) flush(items []interface{}) error {
+	r.ml.Lock()
+	defer r.ml.Unlock()
+	r.batches = append(r.batches, items)
+	return nil
+}
+
+func (r *recorder) total() int {
+	r.ml.Lock()
+	defer r.ml.Unlock()
+	return len(r.batches)
+}
+
+func TestMaxItems(t *testing.T) {
+	var rec recorder
+	b := batch.New(batch.Config{MaxItems: 2}, rec.flush)
+
+	for i := 0; i < 5; i++ {
+		if err := b.Add(i); err != nil {
+			t.Fatalf("Should have added item: %+v", err)
+		}
+	}
+
+	if rec.total() != 2 || b.Pending() != 1 {
+		t.Fatalf("Should have flushed full batches only: %+v", rec.batches)
+	}
+
+	if err := b.Close(); err != nil {
+		t.Fatalf("Should have drained on close: %+v", err)
+	}
+
+	expected := [][]interface{}{{0, 1}, {2, 3}, {4}}
+	if !reflect.DeepEqual(rec.batches, expected) {
+		t.Fatalf("Should have flushed all items in order: %+v", rec.batches)
+	}
+
+	if err := b.Add(5); err != batch.ErrClosed {
+		t.Fatalf("Should have rejected add after close: %+v", err)
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	var rec recorder
+	b := batch.New(batch.Config{
+		MaxBytes: 10,
+		Sizer: func(item interface{}) int {
+			return len(item.(string))
+		},
+	}, rec.flush)
+
+	b.Add("hello")
+	b.Add("hi")
+	if rec.total() != 0 {
+		t.Fatalf("Should not have flushed under byte budget")
+	}
+
+	b.Add("world")
+	if rec.total() != 1 || len(rec.batches[0]) != 3 {
+		t.Fatalf("Should have flushed once byte budget was reached: %+v", rec.batches)
+	}
+}
+
+func TestInterval(t *testing.T) {
+	var rec recorder
+	b := batch.New(batch.Config{Interval: 10 * time.Millisecond}, rec.flush)
+	defer b.Close()
+
+	b.Add(1)
+
+	deadline := time.Now().Add(time.Second)
+	for rec.total() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if rec.total() != 1 {
+		t.Fatalf("Should have flushed on interval")
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var attempts int
+	var failed []interface{}
+
+	bad := errors.New("unavailable")
+	b := batch.New(batch.Config{
+		MaxItems:   1,
+		Retries:    2,
+		RetryDelay: time.Millisecond,
+		OnError: func(items []interface{}, err error) {
+			failed = items
+		},
+	}, func(items []interface{}) error {
+		attempts++
+		if attempts < 3 {
+			return bad
+		}
+		return nil
+	})
+
+	if err := b.Add(1); err != nil || attempts != 3 {
+		t.Fatalf("Should have succeeded after retries: %d %+v", attempts, err)
+	}
+
+	attempts = -10
+	if err := b.Add(2); err != bad || len(failed) != 1 {
+		t.Fatalf("Should have reported failed batch after retries: %+v %+v", err, failed)
+	}
+}
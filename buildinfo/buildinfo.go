@@ -0,0 +1,221 @@
+// Package buildinfo reports the version of the running binary and the state
+// of the Go runtime, as metrics fields, an http.Handler and a startup entry.
+//
+// Version details are set at build time through ldflags:
+//
+//	go build -ldflags "-X github.com/influx6/faux/buildinfo.Version=1.2.0 \
+//		-X github.com/influx6/faux/buildinfo.Commit=$(git rev-parse HEAD) \
+//		-X github.com/influx6/faux/buildinfo.Date=$(date -u +%FT%TZ)"
+//
+// Values not provided are filled from the module information embedded by the
+// go tool where available.
+package buildinfo
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+)
+
+// variables set through ldflags.
+var (
+	Version = ""
+	Commit  = ""
+	Date    = ""
+)
+
+var (
+	started = time.Now()
+	once    sync.Once
+	build   Build
+)
+
+// Build contains the version details of the running binary.
+type Build struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	Modified  bool   `json:"modified"`
+	Module    string `json:"module"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+// Runtime contains a snapshot of the state of the Go runtime.
+type Runtime struct {
+	Hostname   string        `json:"hostname"`
+	PID        int           `json:"pid"`
+	Uptime     time.Duration `json:"uptime"`
+	CPUs       int           `json:"cpus"`
+	Goroutines int           `json:"goroutines"`
+	HeapAlloc  uint64        `json:"heap_alloc"`
+	HeapSys    uint64        `json:"heap_sys"`
+	NumGC      uint32        `json:"num_gc"`
+	PauseTotal time.Duration `json:"pause_total"`
+}
+
+// Info combines the build details with a runtime snapshot.
+type Info struct {
+	Build   Build   `json:"build"`
+	Runtime Runtime `json:"runtime"`
+}
+
+// Get returns the build details of the running binary. The details are
+// resolved once, with ldflags values taking precedence over the embedded
+// module information.
+func Get() Build {
+	once.Do(func() {
+		build = Build{
+			Version:   Version,
+			Commit:    Commit,
+			Date:      Date,
+			GoVersion: runtime.Version(),
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+		}
+
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+
+		build.Module = info.Main.Path
+		if build.Version == "" && info.Main.Version != "(devel)" {
+			build.Version = info.Main.Version
+		}
+
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if build.Commit == "" {
+					build.Commit = setting.Value
+				}
+			case "vcs.time":
+				if build.Date == "" {
+					build.Date = setting.Value
+				}
+			case "vcs.modified":
+				build.Modified = setting.Value == "true"
+			}
+		}
+	})
+
+	return build
+}
+
+// Stats returns a snapshot of the Go runtime.
+func Stats() Runtime {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	hostname, _ := os.Hostname()
+
+	return Runtime{
+		Hostname:   hostname,
+		PID:        os.Getpid(),
+		Uptime:     time.Since(started),
+		CPUs:       runtime.NumCPU(),
+		Goroutines: runtime.NumGoroutine(),
+		HeapAlloc:  mem.HeapAlloc,
+		HeapSys:    mem.HeapSys,
+		NumGC:      mem.NumGC,
+		PauseTotal: time.Duration(mem.PauseTotalNs),
+	}
+}
+
+// Current returns the build details and a runtime snapshot.
+func Current() Info {
+	return Info{Build: Get(), Runtime: Stats()}
+}
+
+//==============================================================================
+
+// Fields returns the build details as metrics fields.
+func (b Build) Fields() metrics.Field {
+	return metrics.Field{
+		"version":    b.Version,
+		"commit":     b.Commit,
+		"build_date": b.Date,
+		"modified":   b.Modified,
+		"module":     b.Module,
+		"go_version": b.GoVersion,
+		"os":         b.OS,
+		"arch":       b.Arch,
+	}
+}
+
+// Fields returns the runtime snapshot as metrics fields.
+func (r Runtime) Fields() metrics.Field {
+	return metrics.Field{
+		"hostname":    r.Hostname,
+		"pid":         r.PID,
+		"uptime":      r.Uptime,
+		"cpus":        r.CPUs,
+		"goroutines":  r.Goroutines,
+		"heap_alloc":  r.HeapAlloc,
+		"heap_sys":    r.HeapSys,
+		"num_gc":      r.NumGC,
+		"pause_total": r.PauseTotal,
+	}
+}
+
+// Enrich returns a metrics.EntryMod which adds the version and commit to
+// every entry, for use with metrics.New.
+func Enrich() metrics.EntryMod {
+	b := Get()
+	return metrics.WithFields(metrics.Field{
+		"version": b.Version,
+		"commit":  b.Commit,
+	})
+}
+
+// Collector returns a metrics.Collector which produces an entry with the
+// id "buildinfo:runtime" carrying a runtime snapshot when collected.
+func Collector() metrics.Collector {
+	return metrics.Collect(func(name string) metrics.Entry {
+		var en metrics.Entry
+		metrics.Apply(&en,
+			metrics.Info("Runtime stats"),
+			metrics.WithID("buildinfo:runtime"),
+			metrics.WithFields(Stats().Fields()),
+			metrics.With("collector", name),
+		)
+		return en
+	})
+}
+
+// Announce emits a startup entry with the id "buildinfo:startup" carrying
+// the build details and runtime snapshot.
+func Announce(m metrics.Metrics) error {
+	fields := Get().Fields()
+	for k, v := range Stats().Fields() {
+		fields[k] = v
+	}
+
+	return m.Emit(metrics.Info("Starting %s", binary()), metrics.WithID("buildinfo:startup"), metrics.WithFields(fields))
+}
+
+// Handler returns a http.Handler which responds with the build details and
+// a runtime snapshot as JSON.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(Current()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func binary() string {
+	if b := Get(); b.Module != "" {
+		return b.Module
+	}
+	return os.Args[0]
+}
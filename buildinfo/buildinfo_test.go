@@ -0,0 +1,78 @@
+package buildinfo_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influx6/faux/buildinfo"
+	"github.com/influx6/faux/metrics"
+	"github.com/influx6/faux/tester"
+)
+
+func init() {
+	buildinfo.Version = "v1.2.3-test"
+}
+
+func TestAnnounce(t *testing.T) {
+	var rec tester.Recorder
+	m := metrics.New(&rec, buildinfo.Collector())
+
+	if err := buildinfo.Announce(m); err != nil {
+		t.Fatalf("Should have emitted startup entry: %+v", err)
+	}
+
+	entries := rec.Find("buildinfo:startup")
+	if len(entries) != 1 {
+		t.Fatalf("Should have recorded startup entry: %+v", rec.Entries())
+	}
+
+	if entries[0].Field["go_version"] == "" {
+		t.Fatalf("Should have carried go version: %+v", entries[0].Field)
+	}
+
+	if err := m.CollectMetrics("test"); err != nil {
+		t.Fatalf("Should have collected runtime stats: %+v", err)
+	}
+
+	if entries := rec.Find("buildinfo:runtime"); len(entries) != 1 || entries[0].Field["goroutines"] == 0 {
+		t.Fatalf("Should have recorded runtime entry: %+v", entries)
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	var rec tester.Recorder
+	m := metrics.New(&rec, buildinfo.Enrich())
+
+	if err := m.Emit(metrics.Info("Request served"), metrics.WithID("http:request")); err != nil {
+		t.Fatalf("Should have emitted entry: %+v", err)
+	}
+
+	entries := rec.Find("http:request")
+	if len(entries) != 1 {
+		t.Fatalf("Should have recorded entry: %+v", rec.Entries())
+	}
+
+	build := buildinfo.Get()
+	if version := entries[0].Field["version"]; version != "v1.2.3-test" {
+		t.Fatalf("Should have enriched entry with version: %+v", entries[0].Field)
+	}
+
+	if commit, ok := entries[0].Field["commit"]; !ok || commit != build.Commit {
+		t.Fatalf("Should have enriched entry with commit: %+v", entries[0].Field)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	res := httptest.NewRecorder()
+	buildinfo.Handler().ServeHTTP(res, httptest.NewRequest("GET", "/version", nil))
+
+	var info buildinfo.Info
+	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+		t.Fatalf("Should have decoded response: %+v", err)
+	}
+
+	if info.Build.GoVersion == "" || info.Runtime.CPUs == 0 {
+		t.Fatalf("Should have reported build and runtime info: %+v", info)
+	}
+}
@@ -0,0 +1,336 @@
+// Package cache provides in-memory LRU and TTL caches with entry and size
+// limits, eviction callbacks, loader deduplication and metrics collection.
+//
+// Keys and values are interface{} rather than type parameters, matching
+// sync2.Flight which deduplicates loads, the rest of faux's APIs, and the
+// pre-generics Go releases faux still builds with. Wrap a Cache in a small
+// typed helper where a fixed key or value type is wanted.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+	"github.com/influx6/faux/sync2"
+)
+
+// EvictFunc defines a function type called with the key and value of an
+// entry removed from the cache.
+type EvictFunc func(key interface{}, value interface{})
+
+// SizeFunc defines a function type which returns the size of a giving value,
+// used to enforce a Config.MaxSize budget.
+type SizeFunc func(value interface{}) int64
+
+// LoadFunc defines a function type which loads the value for a giving key
+// when missing from the cache.
+type LoadFunc func(key interface{}) (interface{}, error)
+
+// Config defines the configuration for a Cache.
+type Config struct {
+	// MaxEntries sets the maximum number of entries, where zero means no limit.
+	MaxEntries int
+
+	// MaxSize sets the maximum total size of all entries as measured by Sizer,
+	// where zero means no limit.
+	MaxSize int64
+
+	// Sizer returns the size of each value, required when MaxSize is set.
+	Sizer SizeFunc
+
+	// TTL sets the default lifetime of entries, where zero means entries
+	// never expire.
+	TTL time.Duration
+
+	// OnEvict is called for every entry removed due to limits, expiry or
+	// explicit deletion.
+	OnEvict EvictFunc
+}
+
+// Stats defines the counters of a Cache. Evictions counts entries removed
+// due to limits or expiry, and Deletes counts entries removed by Delete or
+// Purge.
+type Stats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Deletes   int64
+	Entries   int
+	Size      int64
+}
+
+// item holds a single cache entry.
+type item struct {
+	key     interface{}
+	value   interface{}
+	size    int64
+	expires time.Time
+}
+
+// Cache implements a concurrency-safe least-recently-used cache with optional
+// expiry of entries.
+type Cache struct {
+	config    Config
+	hits      int64
+	misses    int64
+	evictions int64
+	deletes   int64
+
+	ml    sync.Mutex
+	size  int64
+	order *list.List
+	items map[interface{}]*list.Element
+
+	loads sync2.Flight
+}
+
+// New returns a new instance of a Cache using the provided configuration.
+func New(config Config) *Cache {
+	return &Cache{
+		config: config,
+		order:  list.New(),
+		items:  make(map[interface{}]*list.Element),
+	}
+}
+
+// NewLRU returns a new Cache which holds at most maxEntries entries.
+func NewLRU(maxEntries int, onEvict EvictFunc) *Cache {
+	return New(Config{MaxEntries: maxEntries, OnEvict: onEvict})
+}
+
+// NewTTL returns a new Cache whose entries expire after the giving ttl and
+// holds at most maxEntries entries.
+func NewTTL(ttl time.Duration, maxEntries int, onEvict EvictFunc) *Cache {
+	return New(Config{TTL: ttl, MaxEntries: maxEntries, OnEvict: onEvict})
+}
+
+// Get returns the value of the giving key if it exists and has not expired.
+func (c *Cache) Get(key interface{}) (interface{}, bool) {
+	c.ml.Lock()
+	elem, ok := c.items[key]
+	if !ok {
+		c.ml.Unlock()
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+
+	it := elem.Value.(*item)
+	if !it.expires.IsZero() && time.Now().After(it.expires) {
+		c.removeElement(elem)
+		c.ml.Unlock()
+
+		atomic.AddInt64(&c.misses, 1)
+		c.evicted(it)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	c.ml.Unlock()
+
+	atomic.AddInt64(&c.hits, 1)
+	return it.value, true
+}
+
+// Set adds the giving key and value into the cache using the default TTL.
+func (c *Cache) Set(key interface{}, value interface{}) {
+	c.SetWithTTL(key, value, c.config.TTL)
+}
+
+// SetWithTTL adds the giving key and value into the cache which expires after
+// the provided ttl. A zero ttl means the entry never expires.
+func (c *Cache) SetWithTTL(key interface{}, value interface{}, ttl time.Duration) {
+	var size int64
+	if c.config.Sizer != nil {
+		size = c.config.Sizer(value)
+	}
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	c.ml.Lock()
+
+	var removed []*item
+	if elem, ok := c.items[key]; ok {
+		it := elem.Value.(*item)
+		c.size += size - it.size
+		it.value, it.size, it.expires = value, size, expires
+		c.order.MoveToFront(elem)
+	} else {
+		c.items[key] = c.order.PushFront(&item{key: key, value: value, size: size, expires: expires})
+		c.size += size
+	}
+
+	for c.overLimit() {
+		back := c.order.Back()
+		if back == nil {
+			break
+		}
+
+		removed = append(removed, c.removeElement(back))
+	}
+
+	c.ml.Unlock()
+
+	for _, it := range removed {
+		c.evicted(it)
+	}
+}
+
+// Delete removes the giving key from the cache, returning true if it existed.
+func (c *Cache) Delete(key interface{}) bool {
+	c.ml.Lock()
+	elem, ok := c.items[key]
+	if !ok {
+		c.ml.Unlock()
+		return false
+	}
+
+	it := c.removeElement(elem)
+	c.ml.Unlock()
+
+	c.deleted(it)
+	return true
+}
+
+// Len returns the total entries in the cache, including expired entries not
+// yet removed.
+func (c *Cache) Len() int {
+	c.ml.Lock()
+	defer c.ml.Unlock()
+	return c.order.Len()
+}
+
+// Keys returns all keys within the cache from most to least recently used.
+func (c *Cache) Keys() []interface{} {
+	c.ml.Lock()
+	defer c.ml.Unlock()
+
+	keys := make([]interface{}, 0, c.order.Len())
+	for elem := c.order.Front(); elem != nil; elem = elem.Next() {
+		keys = append(keys, elem.Value.(*item).key)
+	}
+
+	return keys
+}
+
+// Expire removes all expired entries from the cache, returning the total removed.
+func (c *Cache) Expire() int {
+	now := time.Now()
+
+	c.ml.Lock()
+	var removed []*item
+	for elem := c.order.Back(); elem != nil; {
+		prev := elem.Prev()
+
+		it := elem.Value.(*item)
+		if !it.expires.IsZero() && now.After(it.expires) {
+			removed = append(removed, c.removeElement(elem))
+		}
+
+		elem = prev
+	}
+	c.ml.Unlock()
+
+	for _, it := range removed {
+		c.evicted(it)
+	}
+
+	return len(removed)
+}
+
+// Purge removes all entries from the cache.
+func (c *Cache) Purge() {
+	c.ml.Lock()
+	var removed []*item
+	for elem := c.order.Back(); elem != nil; elem = c.order.Back() {
+		removed = append(removed, c.removeElement(elem))
+	}
+	c.ml.Unlock()
+
+	for _, it := range removed {
+		c.deleted(it)
+	}
+}
+
+// Stats returns the current counters of the cache.
+func (c *Cache) Stats() Stats {
+	c.ml.Lock()
+	entries, size := c.order.Len(), c.size
+	c.ml.Unlock()
+
+	return Stats{
+		Hits:      atomic.LoadInt64(&c.hits),
+		Misses:    atomic.LoadInt64(&c.misses),
+		Evictions: atomic.LoadInt64(&c.evictions),
+		Deletes:   atomic.LoadInt64(&c.deletes),
+		Entries:   entries,
+		Size:      size,
+	}
+}
+
+// Collect implements the metrics.Collector interface, returning an Entry
+// with the hit, miss, eviction and delete counters of the cache.
+func (c *Cache) Collect(id string) metrics.Entry {
+	stats := c.Stats()
+
+	var en metrics.Entry
+	metrics.Apply(&en,
+		metrics.Info("cache stats"),
+		metrics.WithID(id),
+		metrics.Type("cache"),
+		metrics.WithFields(metrics.Field{
+			"hits":      stats.Hits,
+			"misses":    stats.Misses,
+			"evictions": stats.Evictions,
+			"deletes":   stats.Deletes,
+			"entries":   stats.Entries,
+			"size":      stats.Size,
+		}),
+	)
+
+	return en
+}
+
+// overLimit returns true if the cache exceeds it's entry or size limits.
+// It must be called with the lock held.
+func (c *Cache) overLimit() bool {
+	if c.config.MaxEntries > 0 && c.order.Len() > c.config.MaxEntries {
+		return true
+	}
+
+	return c.config.MaxSize > 0 && c.size > c.config.MaxSize
+}
+
+// removeElement removes the giving element from the cache.
+// It must be called with the lock held.
+func (c *Cache) removeElement(elem *list.Element) *item {
+	it := c.order.Remove(elem).(*item)
+	delete(c.items, it.key)
+	c.size -= it.size
+	return it
+}
+
+// evicted records the eviction of the giving item and calls the eviction
+// callback. It must be called without the lock held.
+func (c *Cache) evicted(it *item) {
+	atomic.AddInt64(&c.evictions, 1)
+	c.removed(it)
+}
+
+// deleted records the explicit deletion of the giving item and calls the
+// eviction callback. It must be called without the lock held.
+func (c *Cache) deleted(it *item) {
+	atomic.AddInt64(&c.deletes, 1)
+	c.removed(it)
+}
+
+func (c *Cache) removed(it *item) {
+	if c.config.OnEvict != nil {
+		c.config.OnEvict(it.key, it.value)
+	}
+}
@@ -0,0 +1,151 @@
+package cache_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/cache"
+	"github.com/influx6/faux/metrics"
+	"github.com/influx6/faux/metrics/memory"
+)
+
+func TestLRU(t *testing.T) {
+	var evicted []interface{}
+
+	c := cache.NewLRU(2, func(key, value interface{}) {
+		evicted = append(evicted, key)
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("Should have found key %q", "a")
+	}
+
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("Should have evicted least recently used key %q", "b")
+	}
+
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Fatalf("Should have called eviction callback for %q: %+v", "b", evicted)
+	}
+
+	if c.Len() != 2 {
+		t.Fatalf("Should have only two entries: %d", c.Len())
+	}
+	t.Logf("Should have evicted least recently used key")
+
+	stats := c.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Evictions != 1 {
+		t.Fatalf("Should have matched expected stats: %+v", stats)
+	}
+	t.Logf("Should have matched expected stats")
+
+	c.Delete("a")
+	c.Purge()
+
+	stats = c.Stats()
+	if stats.Evictions != 1 || stats.Deletes != 2 || len(evicted) != 3 {
+		t.Fatalf("Should have counted deletes apart from evictions: %+v", stats)
+	}
+	t.Logf("Should have counted deletes apart from evictions")
+}
+
+func TestTTL(t *testing.T) {
+	c := cache.NewTTL(20*time.Millisecond, 0, nil)
+	c.Set("a", 1)
+	c.SetWithTTL("b", 2, 0)
+
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("Should have found key %q", "a")
+	}
+
+	<-time.After(30 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("Should have expired key %q", "a")
+	}
+
+	if _, ok := c.Get("b"); !ok {
+		t.Fatalf("Should have retained key %q without expiry", "b")
+	}
+	t.Logf("Should have expired keys after ttl")
+}
+
+func TestMaxSize(t *testing.T) {
+	c := cache.New(cache.Config{
+		MaxSize: 10,
+		Sizer: func(value interface{}) int64 {
+			return int64(len(value.(string)))
+		},
+	})
+
+	c.Set("a", "hello")
+	c.Set("b", "world")
+	c.Set("c", "!")
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("Should have evicted key %q to satisfy size limit", "a")
+	}
+
+	if stats := c.Stats(); stats.Size != 6 {
+		t.Fatalf("Should have total size of 6: %d", stats.Size)
+	}
+	t.Logf("Should have evicted keys to satisfy size limit")
+}
+
+func TestGetOrLoad(t *testing.T) {
+	var calls int64
+	c := cache.NewLRU(10, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			value, err := c.GetOrLoad("key", func(key interface{}) (interface{}, error) {
+				atomic.AddInt64(&calls, 1)
+				<-time.After(10 * time.Millisecond)
+				return "value", nil
+			})
+
+			if err != nil || value != "value" {
+				t.Errorf("Should have loaded value: %+v %+q", value, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if atomic.LoadInt64(&calls) != 1 {
+		t.Fatalf("Should have called loader once: %d", calls)
+	}
+	t.Logf("Should have called loader once")
+}
+
+func TestCollect(t *testing.T) {
+	c := cache.NewLRU(10, nil)
+	c.Set("a", 1)
+	c.Get("a")
+	c.Get("b")
+
+	var mem memory.Memory
+	if err := metrics.New(&mem, c).CollectMetrics("cache:test"); err != nil {
+		t.Fatalf("Should have collected metrics: %+q", err)
+	}
+
+	if len(mem.Data) != 1 {
+		t.Fatalf("Should have received one entry: %d", len(mem.Data))
+	}
+
+	if hits, _ := mem.Data[0].Field.GetInt64("hits"); hits != 1 {
+		t.Fatalf("Should have received hit count of 1: %d", hits)
+	}
+	t.Logf("Should have collected cache metrics")
+}
@@ -0,0 +1,20 @@
+package cache
+
+// GetOrLoad returns the value of the giving key if it is cached, else it
+// calls the loader and caches it's result. Concurrent calls for the same
+// missing key share a single call to the loader.
+func (c *Cache) GetOrLoad(key interface{}, loader LoadFunc) (interface{}, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	value, err, _ := c.loads.Do(key, func() (interface{}, error) {
+		value, err := loader(key)
+		if err == nil {
+			c.Set(key, value)
+		}
+		return value, err
+	})
+
+	return value, err
+}
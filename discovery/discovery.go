@@ -0,0 +1,211 @@
+// Package discovery finds peer processes through static lists or multicast
+// announcements, tracking them as a membership which notifies listeners as
+// peers join, change and leave.
+package discovery
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+)
+
+// Peer defines a process which can be connected to.
+type Peer struct {
+	ID   string            `json:"id"`
+	Addr string            `json:"addr"`
+	Meta map[string]string `json:"meta,omitempty"`
+}
+
+// Provider defines an interface for a source of peers.
+type Provider interface {
+	Peers(context.Context) ([]Peer, error)
+}
+
+// ProviderFunc implements the Provider interface for a function.
+type ProviderFunc func(context.Context) ([]Peer, error)
+
+// Peers implements the Provider interface.
+func (fn ProviderFunc) Peers(ctx context.Context) ([]Peer, error) {
+	return fn(ctx)
+}
+
+// Static returns a Provider which always returns peers for the giving
+// addresses, using the address as the peer id.
+func Static(addrs ...string) Provider {
+	peers := make([]Peer, len(addrs))
+	for index, addr := range addrs {
+		peers[index] = Peer{ID: addr, Addr: addr}
+	}
+
+	return ProviderFunc(func(context.Context) ([]Peer, error) {
+		return peers, nil
+	})
+}
+
+//==============================================================================
+
+// EventType defines the kind of a membership change.
+type EventType int
+
+// event types.
+const (
+	Joined EventType = iota + 1
+	Updated
+	Left
+)
+
+// String returns the name of the event type.
+func (e EventType) String() string {
+	switch e {
+	case Joined:
+		return "joined"
+	case Updated:
+		return "updated"
+	case Left:
+		return "left"
+	}
+	return "unknown"
+}
+
+// Event defines a change to the membership.
+type Event struct {
+	Type EventType
+	Peer Peer
+}
+
+// Listener defines a function type which receives membership events.
+type Listener func(Event)
+
+// Membership tracks the peers returned by a set of providers, notifying
+// listeners of changes on every refresh.
+type Membership struct {
+	self      string
+	providers []Provider
+	metrics   metrics.Metrics
+
+	ml        sync.Mutex
+	peers     map[string]Peer
+	listeners []Listener
+}
+
+// NewMembership returns a new instance of a Membership. Peers with the id
+// of self are ignored, which allows a process to share a peer list with
+// it's siblings. The provided metrics may be nil.
+func NewMembership(self string, m metrics.Metrics, providers ...Provider) *Membership {
+	return &Membership{
+		self:      self,
+		providers: providers,
+		metrics:   m,
+		peers:     make(map[string]Peer),
+	}
+}
+
+// Listen adds the listener to be called on every membership event.
+func (m *Membership) Listen(fn Listener) {
+	m.ml.Lock()
+	defer m.ml.Unlock()
+	m.listeners = append(m.listeners, fn)
+}
+
+// Members returns the current peers, sorted by id.
+func (m *Membership) Members() []Peer {
+	m.ml.Lock()
+	defer m.ml.Unlock()
+
+	peers := make([]Peer, 0, len(m.peers))
+	for _, peer := range m.peers {
+		peers = append(peers, peer)
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].ID < peers[j].ID
+	})
+	return peers
+}
+
+// Refresh queries all providers, updating the membership and notifying
+// listeners of the differences. A provider which fails keeps no peers
+// from being removed, so a transient error does not evict the membership.
+func (m *Membership) Refresh(ctx context.Context) error {
+	found := make(map[string]Peer)
+
+	var lastErr error
+	for _, provider := range m.providers {
+		peers, err := provider.Peers(ctx)
+		if err != nil {
+			lastErr = err
+			if m.metrics != nil {
+				m.metrics.Emit(metrics.Error(err), metrics.WithID("discovery:provider"))
+			}
+			continue
+		}
+
+		for _, peer := range peers {
+			if peer.ID != m.self {
+				found[peer.ID] = peer
+			}
+		}
+	}
+
+	var events []Event
+
+	m.ml.Lock()
+	for id, peer := range found {
+		current, ok := m.peers[id]
+		switch {
+		case !ok:
+			events = append(events, Event{Type: Joined, Peer: peer})
+		case current.Addr != peer.Addr || !reflect.DeepEqual(current.Meta, peer.Meta):
+			events = append(events, Event{Type: Updated, Peer: peer})
+		}
+		m.peers[id] = peer
+	}
+
+	if lastErr == nil {
+		for id, peer := range m.peers {
+			if _, ok := found[id]; !ok {
+				events = append(events, Event{Type: Left, Peer: peer})
+				delete(m.peers, id)
+			}
+		}
+	}
+
+	listeners := m.listeners
+	m.ml.Unlock()
+
+	for _, event := range events {
+		if m.metrics != nil {
+			m.metrics.Emit(metrics.Info("Peer %s", event.Type), metrics.WithID("discovery:"+event.Type.String()), metrics.WithFields(metrics.Field{
+				"peer": event.Peer.ID,
+				"addr": event.Peer.Addr,
+			}))
+		}
+
+		for _, fn := range listeners {
+			fn(event)
+		}
+	}
+
+	return lastErr
+}
+
+// Run calls Refresh immediately and on every interval until the context is
+// done.
+func (m *Membership) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		m.Refresh(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package discovery_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/discovery"
+	"github.com/influx6/faux/metrics"
+	"github.com/influx6/faux/tester"
+)
+
+func TestMembership(t *testing.T) {
+	var rec tester.Recorder
+	var events []discovery.Event
+
+	peers := []discovery.Peer{
+		{ID: "self", Addr: "10.0.0.1:80"},
+		{ID: "a", Addr: "10.0.0.2:80"},
+		{ID: "b", Addr: "10.0.0.3:80"},
+	}
+
+	var failing error
+	provider := discovery.ProviderFunc(func(context.Context) ([]discovery.Peer, error) {
+		return peers, failing
+	})
+
+	members := discovery.NewMembership("self", metrics.New(&rec), provider, discovery.Static("10.0.0.9:80"))
+	members.Listen(func(ev discovery.Event) {
+		events = append(events, ev)
+	})
+
+	members.Refresh(context.Background())
+	if len(events) != 3 || len(members.Members()) != 3 {
+		t.Fatalf("Should have joined all peers except self: %+v", events)
+	}
+
+	events = nil
+	peers = []discovery.Peer{{ID: "a", Addr: "10.0.0.4:80"}}
+	members.Refresh(context.Background())
+
+	if len(events) != 2 {
+		t.Fatalf("Should have updated a and removed b: %+v", events)
+	}
+
+	for _, ev := range events {
+		if (ev.Peer.ID == "a" && ev.Type != discovery.Updated) || (ev.Peer.ID == "b" && ev.Type != discovery.Left) {
+			t.Fatalf("Should have received correct event: %+v", ev)
+		}
+	}
+
+	events = nil
+	peers = nil
+	failing = errors.New("unreachable")
+	if err := members.Refresh(context.Background()); err != failing {
+		t.Fatalf("Should have returned provider error: %+v", err)
+	}
+
+	if len(events) != 0 || len(members.Members()) != 2 {
+		t.Fatalf("Should have kept members on provider failure: %+v", members.Members())
+	}
+
+	if len(rec.Find("discovery:joined")) != 3 || len(rec.Find("discovery:provider")) != 1 {
+		t.Fatalf("Should have emitted membership entries: %+v", rec.Entries())
+	}
+}
+
+func TestBeacon(t *testing.T) {
+	group := "239.255.77.78:17778"
+
+	first, err := discovery.NewBeacon("faux", group, discovery.Peer{ID: "first", Addr: "10.0.0.1:80"}, time.Second, nil)
+	if err != nil {
+		t.Skipf("Multicast unavailable: %+v", err)
+	}
+
+	second, err := discovery.NewBeacon("faux", group, discovery.Peer{ID: "second", Addr: "10.0.0.2:80"}, time.Second, nil)
+	if err != nil {
+		first.Close()
+		t.Skipf("Multicast unavailable: %+v", err)
+	}
+
+	if err := first.Run(context.Background(), 0); err != discovery.ErrInvalidInterval {
+		t.Fatalf("Should have rejected non-positive interval: %+v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	results := make(chan error, 2)
+	go func() { results <- first.Run(ctx, 10*time.Millisecond) }()
+	go func() { results <- second.Run(ctx, 10*time.Millisecond) }()
+
+	heard := func(b *discovery.Beacon, id string) bool {
+		peers, _ := b.Peers(context.Background())
+		for _, peer := range peers {
+			if peer.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && !(heard(first, "second") && heard(second, "first")) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	found := heard(first, "second") && heard(second, "first")
+
+	if heard(first, "first") {
+		t.Fatalf("Should have ignored own announcements")
+	}
+
+	cancel()
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Fatalf("Should have stopped cleanly on cancel: %+v", err)
+		}
+	}
+
+	if err := first.Close(); err != discovery.ErrBeaconClosed {
+		t.Fatalf("Should have already closed beacon: %+v", err)
+	}
+
+	if !found {
+		t.Skipf("Multicast loopback unavailable, beacons did not hear each other")
+	}
+}
+
+func TestBeaconClose(t *testing.T) {
+	beacon, err := discovery.NewBeacon("faux", "239.255.77.78:17779", discovery.Peer{ID: "solo"}, time.Second, nil)
+	if err != nil {
+		t.Skipf("Multicast unavailable: %+v", err)
+	}
+
+	if err := beacon.Close(); err != nil {
+		t.Fatalf("Should have closed unused beacon: %+v", err)
+	}
+
+	if err := beacon.Run(context.Background(), time.Second); err != discovery.ErrBeaconClosed {
+		t.Fatalf("Should have rejected run after close: %+v", err)
+	}
+}
@@ -0,0 +1,213 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+)
+
+// errors.
+var (
+	ErrInvalidInterval = errors.New("announce interval must be positive")
+	ErrBeaconClosed    = errors.New("beacon already closed")
+)
+
+// DefaultGroup defines the multicast group used by Beacons when none is
+// provided.
+const DefaultGroup = "239.255.77.77:7777"
+
+// maxBeaconSize defines the largest announcement accepted by a Beacon.
+const maxBeaconSize = 8192
+
+// announcement defines the payload sent by a Beacon.
+type announcement struct {
+	Service string `json:"service"`
+	Peer    Peer   `json:"peer"`
+}
+
+// Beacon implements the Provider interface by periodically announcing a
+// peer over UDP multicast and collecting the announcements of other peers
+// of the same service on the local network. Peers not heard from within
+// the ttl are dropped.
+type Beacon struct {
+	service string
+	self    Peer
+	ttl     time.Duration
+	group   *net.UDPAddr
+	conn    *net.UDPConn
+	metrics metrics.Metrics
+
+	closer sync.Once
+	closed chan struct{}
+
+	ml   sync.Mutex
+	seen map[string]seenPeer
+}
+
+type seenPeer struct {
+	peer Peer
+	at   time.Time
+}
+
+// NewBeacon returns a new instance of a Beacon which announces self for the
+// giving service on the multicast group, or DefaultGroup if empty. Failed
+// announcements are emitted to the provided metrics, which may be nil. The
+// Beacon must be closed through Close or by the context given to Run.
+func NewBeacon(service string, group string, self Peer, ttl time.Duration, m metrics.Metrics) (*Beacon, error) {
+	if group == "" {
+		group = DefaultGroup
+	}
+
+	addr, err := net.ResolveUDPAddr("udp4", group)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenMulticastUDP("udp4", nil, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Beacon{
+		service: service,
+		self:    self,
+		ttl:     ttl,
+		group:   addr,
+		conn:    conn,
+		metrics: m,
+		closed:  make(chan struct{}),
+		seen:    make(map[string]seenPeer),
+	}, nil
+}
+
+// Peers implements the Provider interface.
+func (b *Beacon) Peers(context.Context) ([]Peer, error) {
+	b.ml.Lock()
+	defer b.ml.Unlock()
+
+	var peers []Peer
+	for id, seen := range b.seen {
+		if time.Since(seen.at) > b.ttl {
+			delete(b.seen, id)
+			continue
+		}
+		peers = append(peers, seen.peer)
+	}
+	return peers, nil
+}
+
+// Close stops the beacon and releases it's socket.
+func (b *Beacon) Close() error {
+	err := ErrBeaconClosed
+	b.closer.Do(func() {
+		close(b.closed)
+		err = b.conn.Close()
+	})
+	return err
+}
+
+// Run announces the peer on every interval and collects announcements
+// until the context is done or the beacon is closed, closing the beacon
+// after.
+func (b *Beacon) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return ErrInvalidInterval
+	}
+
+	select {
+	case <-b.closed:
+		return ErrBeaconClosed
+	default:
+	}
+
+	sender, err := net.DialUDP("udp4", nil, b.group)
+	if err != nil {
+		b.Close()
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.Close()
+		case <-done:
+		}
+	}()
+
+	go b.announce(sender, interval)
+
+	buf := make([]byte, maxBeaconSize)
+	for {
+		n, _, err := b.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-b.closed:
+				return nil
+			default:
+			}
+
+			b.Close()
+			return err
+		}
+
+		var an announcement
+		if err := json.Unmarshal(buf[:n], &an); err != nil {
+			continue
+		}
+
+		if an.Service != b.service || an.Peer.ID == "" || an.Peer.ID == b.self.ID {
+			continue
+		}
+
+		b.ml.Lock()
+		b.seen[an.Peer.ID] = seenPeer{peer: an.Peer, at: time.Now()}
+		b.ml.Unlock()
+	}
+}
+
+// announce writes the announcement on every interval until the beacon is
+// closed, emitting failed writes.
+func (b *Beacon) announce(conn *net.UDPConn, interval time.Duration) {
+	defer conn.Close()
+
+	payload, err := json.Marshal(announcement{Service: b.service, Peer: b.self})
+	if err != nil {
+		b.failed(err)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := conn.Write(payload); err != nil {
+			b.failed(err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-b.closed:
+			return
+		}
+	}
+}
+
+func (b *Beacon) failed(err error) {
+	if b.metrics == nil {
+		return
+	}
+
+	b.metrics.Emit(metrics.Error(err), metrics.WithID("discovery:announce"), metrics.WithFields(metrics.Field{
+		"service": b.service,
+		"peer":    b.self.ID,
+		"group":   b.group.String(),
+	}))
+}
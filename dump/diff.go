@@ -0,0 +1,191 @@
+package dump
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Difference defines a single difference found between two values at the
+// giving path, i.e `.Name`, `[2]` or `["key"]`.
+type Difference struct {
+	Path  string
+	Left  string
+	Right string
+}
+
+// String returns the string form of the difference.
+func (d Difference) String() string {
+	path := d.Path
+	if path == "" {
+		path = "value"
+	}
+
+	return fmt.Sprintf("%s: %s != %s", path, d.Left, d.Right)
+}
+
+// Differences defines a list of Difference.
+type Differences []Difference
+
+// String returns all differences with one per line.
+func (d Differences) String() string {
+	var bu bytes.Buffer
+	for _, diff := range d {
+		bu.WriteString(diff.String())
+		bu.WriteString("\n")
+	}
+	return bu.String()
+}
+
+// Diff returns all differences between the giving values, walking through
+// structs, maps, slices and pointers. It returns nil if both are equal.
+func Diff(left interface{}, right interface{}) Differences {
+	var diffs Differences
+	differ{visited: make(map[pair]bool)}.diff("", reflect.ValueOf(left), reflect.ValueOf(right), &diffs)
+	return diffs
+}
+
+// pair identifies a pair of reference values already being compared.
+type pair struct {
+	left  uintptr
+	right uintptr
+	kind  reflect.Kind
+}
+
+// differ holds the state of a single diff run.
+type differ struct {
+	visited map[pair]bool
+}
+
+func (d differ) diff(path string, left reflect.Value, right reflect.Value, diffs *Differences) {
+	if !left.IsValid() || !right.IsValid() {
+		if left.IsValid() != right.IsValid() {
+			d.add(path, left, right, diffs)
+		}
+		return
+	}
+
+	if left.Type() != right.Type() {
+		*diffs = append(*diffs, Difference{
+			Path:  path,
+			Left:  left.Type().String(),
+			Right: right.Type().String(),
+		})
+		return
+	}
+
+	switch left.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if left.IsNil() || right.IsNil() {
+			if left.IsNil() != right.IsNil() {
+				d.add(path, left, right, diffs)
+			}
+			return
+		}
+
+		if left.Kind() == reflect.Ptr {
+			if !d.enter(left, right) {
+				return
+			}
+			defer d.leave(left, right)
+		}
+
+		d.diff(path, left.Elem(), right.Elem(), diffs)
+	case reflect.Struct:
+		if left.Type() == timeType && left.CanInterface() {
+			if !left.Interface().(time.Time).Equal(right.Interface().(time.Time)) {
+				d.add(path, left, right, diffs)
+			}
+			return
+		}
+
+		for i := 0; i < left.NumField(); i++ {
+			d.diff(path+"."+left.Type().Field(i).Name, left.Field(i), right.Field(i), diffs)
+		}
+	case reflect.Map:
+		if left.IsNil() != right.IsNil() {
+			d.add(path, left, right, diffs)
+			return
+		}
+
+		if !d.enter(left, right) {
+			return
+		}
+		defer d.leave(left, right)
+
+		keys := left.MapKeys()
+		for _, key := range right.MapKeys() {
+			if !left.MapIndex(key).IsValid() {
+				keys = append(keys, key)
+			}
+		}
+		sortValues(keys)
+
+		for _, key := range keys {
+			d.diff(path+"["+compactString(key)+"]", left.MapIndex(key), right.MapIndex(key), diffs)
+		}
+	case reflect.Slice, reflect.Array:
+		if left.Kind() == reflect.Slice {
+			if left.IsNil() != right.IsNil() {
+				d.add(path, left, right, diffs)
+				return
+			}
+
+			if !d.enter(left, right) {
+				return
+			}
+			defer d.leave(left, right)
+		}
+
+		total := left.Len()
+		if right.Len() > total {
+			total = right.Len()
+		}
+
+		for i := 0; i < total; i++ {
+			var lv, rv reflect.Value
+			if i < left.Len() {
+				lv = left.Index(i)
+			}
+			if i < right.Len() {
+				rv = right.Index(i)
+			}
+
+			d.diff(fmt.Sprintf("%s[%d]", path, i), lv, rv, diffs)
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if left.Pointer() != right.Pointer() {
+			d.add(path, left, right, diffs)
+		}
+	default:
+		if compactString(left) != compactString(right) {
+			d.add(path, left, right, diffs)
+		}
+	}
+}
+
+// enter marks the giving pair of reference values as being compared,
+// returning false if they are already compared higher up, which indicates
+// a cycle.
+func (d differ) enter(left reflect.Value, right reflect.Value) bool {
+	key := pair{left: left.Pointer(), right: right.Pointer(), kind: left.Kind()}
+	if d.visited[key] {
+		return false
+	}
+
+	d.visited[key] = true
+	return true
+}
+
+func (d differ) leave(left reflect.Value, right reflect.Value) {
+	delete(d.visited, pair{left: left.Pointer(), right: right.Pointer(), kind: left.Kind()})
+}
+
+func (d differ) add(path string, left reflect.Value, right reflect.Value, diffs *Differences) {
+	*diffs = append(*diffs, Difference{
+		Path:  path,
+		Left:  compactString(left),
+		Right: compactString(right),
+	})
+}
@@ -0,0 +1,312 @@
+// Package dump provides hex dumping of byte data, pretty printing of arbitrary
+// Go values with depth limits and cycle detection, and diffing of two values.
+package dump
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Hex returns the hex dump of the giving data in the format of `hexdump -C`.
+func Hex(data []byte) string {
+	return hex.Dump(data)
+}
+
+// HexWriter returns a io.WriteCloser which writes a hex dump of all data
+// written into it to w. Close must be called to flush the final line. Use the
+// hexwriter package instead for data as Go escaped bytes.
+func HexWriter(w io.Writer) io.WriteCloser {
+	return hex.Dumper(w)
+}
+
+//==============================================================================
+
+// Config defines the options used to print values.
+type Config struct {
+	// MaxDepth sets the maximum nesting depth printed, where zero means no
+	// limit. Values beyond the depth are printed as "...".
+	MaxDepth int
+
+	// Indent sets the indentation used for nested values, where an empty
+	// value prints everything on a single line.
+	Indent string
+
+	// HideTypes disables printing of type names for structs, maps and slices.
+	HideTypes bool
+}
+
+// Default defines the Config used by Sprint and Fprint.
+var Default = Config{Indent: "  "}
+
+// Compact defines a Config which prints values on a single line.
+var Compact = Config{}
+
+// Sprint returns the pretty printed form of the giving value.
+func Sprint(v interface{}) string {
+	return Default.Sprint(v)
+}
+
+// Fprint writes the pretty printed form of the giving value into w.
+func Fprint(w io.Writer, v interface{}) error {
+	return Default.Fprint(w, v)
+}
+
+// Sprint returns the printed form of the giving value using the config.
+func (c Config) Sprint(v interface{}) string {
+	var bu bytes.Buffer
+	c.Fprint(&bu, v)
+	return bu.String()
+}
+
+// Fprint writes the printed form of the giving value into w using the config.
+func (c Config) Fprint(w io.Writer, v interface{}) error {
+	p := printer{config: c, visited: make(map[visit]bool)}
+	p.print(reflect.ValueOf(v), 0)
+	_, err := p.buf.WriteTo(w)
+	return err
+}
+
+// visit identifies a reference value already being printed.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// printer holds the state of a single print run.
+type printer struct {
+	config  Config
+	buf     bytes.Buffer
+	visited map[visit]bool
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (p *printer) print(val reflect.Value, depth int) {
+	if !val.IsValid() {
+		p.buf.WriteString("nil")
+		return
+	}
+
+	if p.config.MaxDepth > 0 && depth > p.config.MaxDepth {
+		p.buf.WriteString("...")
+		return
+	}
+
+	if val.Type() == timeType && val.CanInterface() {
+		p.buf.WriteString(val.Interface().(time.Time).Format(time.RFC3339Nano))
+		return
+	}
+
+	switch val.Kind() {
+	case reflect.String:
+		p.buf.WriteString(strconv.Quote(val.String()))
+	case reflect.Bool:
+		p.buf.WriteString(strconv.FormatBool(val.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p.buf.WriteString(strconv.FormatInt(val.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		p.buf.WriteString(strconv.FormatUint(val.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		p.buf.WriteString(strconv.FormatFloat(val.Float(), 'g', -1, 64))
+	case reflect.Complex64, reflect.Complex128:
+		p.buf.WriteString(strconv.FormatComplex(val.Complex(), 'g', -1, 128))
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if val.IsNil() {
+			p.buf.WriteString("nil")
+			return
+		}
+		fmt.Fprintf(&p.buf, "%s(%#x)", val.Type(), val.Pointer())
+	case reflect.Interface:
+		if val.IsNil() {
+			p.buf.WriteString("nil")
+			return
+		}
+		p.print(val.Elem(), depth)
+	case reflect.Ptr:
+		if val.IsNil() {
+			p.buf.WriteString("nil")
+			return
+		}
+
+		if !p.enter(val) {
+			fmt.Fprintf(&p.buf, "<cycle %s>", val.Type())
+			return
+		}
+		defer p.leave(val)
+
+		p.buf.WriteString("&")
+		p.print(val.Elem(), depth)
+	case reflect.Struct:
+		p.printStruct(val, depth)
+	case reflect.Map:
+		p.printMap(val, depth)
+	case reflect.Slice:
+		if val.IsNil() {
+			p.buf.WriteString("nil")
+			return
+		}
+
+		if !p.enter(val) {
+			fmt.Fprintf(&p.buf, "<cycle %s>", val.Type())
+			return
+		}
+		defer p.leave(val)
+
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			p.typeName(val)
+			fmt.Fprintf(&p.buf, "%q", val.Bytes())
+			return
+		}
+
+		p.printList(val, depth)
+	case reflect.Array:
+		p.printList(val, depth)
+	}
+}
+
+func (p *printer) printStruct(val reflect.Value, depth int) {
+	tl := val.Type()
+
+	p.typeName(val)
+	p.buf.WriteString("{")
+
+	for i := 0; i < tl.NumField(); i++ {
+		p.newline(depth + 1)
+		p.buf.WriteString(tl.Field(i).Name)
+		p.buf.WriteString(": ")
+		p.print(val.Field(i), depth+1)
+		p.separator(i, tl.NumField())
+	}
+
+	if tl.NumField() > 0 {
+		p.newline(depth)
+	}
+	p.buf.WriteString("}")
+}
+
+func (p *printer) printMap(val reflect.Value, depth int) {
+	if val.IsNil() {
+		p.buf.WriteString("nil")
+		return
+	}
+
+	if !p.enter(val) {
+		fmt.Fprintf(&p.buf, "<cycle %s>", val.Type())
+		return
+	}
+	defer p.leave(val)
+
+	keys := val.MapKeys()
+	sortValues(keys)
+
+	p.typeName(val)
+	p.buf.WriteString("{")
+
+	for index, key := range keys {
+		p.newline(depth + 1)
+		p.print(key, depth+1)
+		p.buf.WriteString(": ")
+		p.print(val.MapIndex(key), depth+1)
+		p.separator(index, len(keys))
+	}
+
+	if len(keys) > 0 {
+		p.newline(depth)
+	}
+	p.buf.WriteString("}")
+}
+
+func (p *printer) printList(val reflect.Value, depth int) {
+	p.typeName(val)
+	p.buf.WriteString("{")
+
+	for i := 0; i < val.Len(); i++ {
+		p.newline(depth + 1)
+		p.print(val.Index(i), depth+1)
+		p.separator(i, val.Len())
+	}
+
+	if val.Len() > 0 {
+		p.newline(depth)
+	}
+	p.buf.WriteString("}")
+}
+
+// enter marks the giving reference value as being printed, returning false
+// if it's already being printed higher up, which indicates a cycle.
+func (p *printer) enter(val reflect.Value) bool {
+	key := visit{ptr: val.Pointer(), typ: val.Type()}
+	if p.visited[key] {
+		return false
+	}
+
+	p.visited[key] = true
+	return true
+}
+
+func (p *printer) leave(val reflect.Value) {
+	delete(p.visited, visit{ptr: val.Pointer(), typ: val.Type()})
+}
+
+func (p *printer) typeName(val reflect.Value) {
+	if !p.config.HideTypes {
+		p.buf.WriteString(val.Type().String())
+	}
+}
+
+func (p *printer) newline(depth int) {
+	if p.config.Indent == "" {
+		return
+	}
+
+	p.buf.WriteString("\n")
+	p.buf.WriteString(strings.Repeat(p.config.Indent, depth))
+}
+
+func (p *printer) separator(index int, total int) {
+	if p.config.Indent != "" {
+		p.buf.WriteString(",")
+		return
+	}
+
+	if index < total-1 {
+		p.buf.WriteString(", ")
+	}
+}
+
+// sortValues sorts map keys by their printed form for stable output.
+func sortValues(vals []reflect.Value) {
+	keys := make([]string, len(vals))
+	for index, val := range vals {
+		keys[index] = compactString(val)
+	}
+
+	sort.Sort(byKey{keys: keys, vals: vals})
+}
+
+// compactString returns the single line printed form of the giving value.
+func compactString(val reflect.Value) string {
+	p := printer{config: Compact, visited: make(map[visit]bool)}
+	p.print(val, 0)
+	return p.buf.String()
+}
+
+// byKey sorts values by their associated string keys.
+type byKey struct {
+	keys []string
+	vals []reflect.Value
+}
+
+func (b byKey) Len() int           { return len(b.keys) }
+func (b byKey) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b byKey) Swap(i, j int) {
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+	b.vals[i], b.vals[j] = b.vals[j], b.vals[i]
+}
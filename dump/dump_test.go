@@ -0,0 +1,125 @@
+package dump_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/influx6/faux/dump"
+)
+
+type node struct {
+	Name   string
+	Labels map[string]int
+	Next   *node
+}
+
+func TestSprint(t *testing.T) {
+	n := &node{Name: "root", Labels: map[string]int{"b": 2, "a": 1}}
+
+	expected := `&dump_test.node{Name: "root", Labels: map[string]int{"a": 1, "b": 2}, Next: nil}`
+	if got := dump.Compact.Sprint(n); got != expected {
+		t.Fatalf("Should have matched printed value: %s", got)
+	}
+	t.Logf("Should have matched printed value")
+
+	pretty := dump.Sprint([]int{1, 2})
+	if pretty != "[]int{\n  1,\n  2,\n}" {
+		t.Fatalf("Should have matched indented value: %q", pretty)
+	}
+	t.Logf("Should have matched indented value")
+}
+
+func TestSprintCycle(t *testing.T) {
+	n := &node{Name: "root"}
+	n.Next = n
+
+	got := dump.Compact.Sprint(n)
+	if !strings.Contains(got, "<cycle *dump_test.node>") {
+		t.Fatalf("Should have detected cycle: %s", got)
+	}
+	t.Logf("Should have detected cycle: %s", got)
+}
+
+func TestSprintDepth(t *testing.T) {
+	n := &node{Name: "a", Next: &node{Name: "b", Next: &node{Name: "c"}}}
+
+	got := dump.Config{MaxDepth: 1, HideTypes: true}.Sprint(n)
+	if got != `&{Name: "a", Labels: nil, Next: &{Name: ..., Labels: ..., Next: ...}}` {
+		t.Fatalf("Should have limited printed depth: %s", got)
+	}
+	t.Logf("Should have limited printed depth")
+}
+
+func TestDiff(t *testing.T) {
+	left := node{Name: "a", Labels: map[string]int{"x": 1, "y": 2}}
+	right := node{Name: "b", Labels: map[string]int{"x": 1, "z": 3}, Next: &node{}}
+
+	diffs := dump.Diff(left, right)
+	if len(diffs) != 4 {
+		t.Fatalf("Should have found four differences: %s", diffs)
+	}
+
+	if diffs[0].String() != `.Name: "a" != "b"` {
+		t.Fatalf("Should have matched first difference: %s", diffs[0])
+	}
+	t.Logf("Should have found differences:\n%s", diffs)
+
+	if diffs := dump.Diff([]int{1, 2}, []int{1, 2}); diffs != nil {
+		t.Fatalf("Should have found no differences: %s", diffs)
+	}
+	t.Logf("Should have found no differences")
+}
+
+func TestDiffCycle(t *testing.T) {
+	left := map[string]interface{}{"name": "a"}
+	left["self"] = left
+
+	right := map[string]interface{}{"name": "b"}
+	right["self"] = right
+
+	diffs := dump.Diff(left, right)
+	if len(diffs) != 1 || diffs[0].Path != `["name"]` {
+		t.Fatalf("Should have compared cyclic maps: %s", diffs)
+	}
+	t.Logf("Should have compared cyclic maps:\n%s", diffs)
+
+	ls := []interface{}{1, nil}
+	ls[1] = ls
+
+	rs := []interface{}{2, nil}
+	rs[1] = rs
+
+	diffs = dump.Diff(ls, rs)
+	if len(diffs) != 1 || diffs[0].Path != "[0]" {
+		t.Fatalf("Should have compared cyclic slices: %s", diffs)
+	}
+	t.Logf("Should have compared cyclic slices:\n%s", diffs)
+
+	if diffs := dump.Diff(left, left); diffs != nil {
+		t.Fatalf("Should have found no differences: %s", diffs)
+	}
+	t.Logf("Should have found no differences")
+}
+
+func TestHexWriter(t *testing.T) {
+	expected := "00000000  66 61 75 78 20 64 75 6d  70 73 20 68 65 78 20 64  |faux dumps hex d|\n" +
+		"00000010  61 74 61 21                                       |ata!|\n"
+
+	if dumped := dump.Hex([]byte("faux dumps hex data!")); dumped != expected {
+		t.Fatalf("Should have matched hex dump: %q", dumped)
+	}
+	t.Logf("Should have matched hex dump")
+
+	var bu bytes.Buffer
+
+	w := dump.HexWriter(&bu)
+	w.Write([]byte("faux dumps"))
+	w.Write([]byte(" hex data!"))
+	w.Close()
+
+	if bu.String() != expected {
+		t.Fatalf("Should have matched streamed hex dump: %q", bu.String())
+	}
+	t.Logf("Should have matched streamed hex dump")
+}
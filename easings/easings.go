@@ -7,7 +7,12 @@ import "math"
 var (
 	// Expo defines the easing functions which returns the provided value after
 	// augmenting with a exponential operation.
-	Expo = New(func(t, m float64) float64 { return math.Pow(t, 6) })
+	Expo = New(func(t, m float64) float64 {
+		if t == 0 {
+			return 0
+		}
+		return math.Pow(2, 10*(t-1))
+	})
 
 	// EaseInExpo defines the ease-in easing function for the expo function.
 	EaseInExpo = Expo
@@ -27,7 +32,7 @@ var (
 	// EaseOutInExpo defines the ease-out-in easing function for the expo function.
 	EaseOutInExpo = New(func(t, m float64) float64 {
 		if t < 0.5 {
-			return 1 - EaseInExpo.Ease(1-(2*t), m)/2
+			return (1 - EaseInExpo.Ease(1-(2*t), m)) / 2
 		}
 
 		return (EaseInExpo.Ease((t*2)-1, m) + 1) / 2
@@ -55,7 +60,7 @@ var (
 	// EaseOutInQuint defines the ease-out-in easing function for the Quint function.
 	EaseOutInQuint = New(func(t, m float64) float64 {
 		if t < 0.5 {
-			return 1 - EaseInQuint.Ease(1-(2*t), m)/2
+			return (1 - EaseInQuint.Ease(1-(2*t), m)) / 2
 		}
 
 		return (EaseInQuint.Ease((t*2)-1, m) + 1) / 2
@@ -83,7 +88,7 @@ var (
 	// EaseOutInQuart defines the ease-out-in easing function for the Quart function.
 	EaseOutInQuart = New(func(t, m float64) float64 {
 		if t < 0.5 {
-			return 1 - EaseInQuart.Ease(1-(2*t), m)/2
+			return (1 - EaseInQuart.Ease(1-(2*t), m)) / 2
 		}
 
 		return (EaseInQuart.Ease((t*2)-1, m) + 1) / 2
@@ -111,7 +116,7 @@ var (
 	// EaseOutInQuad defines the ease-out-in easing function for the Quad function.
 	EaseOutInQuad = New(func(t, m float64) float64 {
 		if t < 0.5 {
-			return 1 - EaseInQuad.Ease(1-(2*t), m)/2
+			return (1 - EaseInQuad.Ease(1-(2*t), m)) / 2
 		}
 
 		return (EaseInQuad.Ease((t*2)-1, m) + 1) / 2
@@ -139,7 +144,7 @@ var (
 	// EaseOutInCubic defines the ease-out-in easing function for the Cubic function.
 	EaseOutInCubic = New(func(t, m float64) float64 {
 		if t < 0.5 {
-			return 1 - EaseInCubic.Ease(1-(2*t), m)/2
+			return (1 - EaseInCubic.Ease(1-(2*t), m)) / 2
 		}
 
 		return (EaseInCubic.Ease((t*2)-1, m) + 1) / 2
@@ -149,8 +154,27 @@ var (
 	// without any addition of ease.
 	Linear = New(func(t, m float64) float64 { return t })
 
-	// Back defines the easing using the back wave function.
-	Back = New(func(t, m float64) float64 { return t * t * (3 * (t - 2)) })
+	// Back defines the easing using the back wave function, which overshoots
+	// backwards before moving forward. m sets the overshoot, defaulting to
+	// 1.70158 when zero.
+	Back = New(func(t, m float64) float64 {
+		if m == 0 {
+			m = 1.70158
+		}
+		return t * t * ((m+1)*t - m)
+	})
+
+	// EaseInBack defines the ease-in easing function for the Back function.
+	EaseInBack = Back
+
+	// EaseOutBack defines the ease-out easing function for the Back function.
+	EaseOutBack = EaseOut(EaseInBack)
+
+	// EaseInOutBack defines the ease-in-out easing function for the Back function.
+	EaseInOutBack = EaseInOut(EaseInBack)
+
+	// EaseOutInBack defines the ease-out-in easing function for the Back function.
+	EaseOutInBack = EaseOutIn(EaseInBack)
 
 	// BounceM defines the easing using the bounce wave function.
 	BounceM = New(func(t, m float64) float64 {
@@ -165,24 +189,98 @@ var (
 		return 1 / ((math.Pow(4, 3-bounce) - 7.5625) * math.Pow(((pow2*3)-2)/(22-t), 2))
 	})
 
+	// Bounce defines the easing using the bounce function, which settles
+	// into the end value with decreasing bounces.
+	Bounce = New(func(t, m float64) float64 {
+		return 1 - EaseOutBounce.Ease(1-t, m)
+	})
+
+	// EaseInBounce defines the ease-in easing function for the Bounce function.
+	EaseInBounce = Bounce
+
+	// EaseOutBounce defines the ease-out easing function for the Bounce function.
+	EaseOutBounce = New(func(t, m float64) float64 {
+		const n, d = 7.5625, 2.75
+
+		switch {
+		case t < 1/d:
+			return n * t * t
+		case t < 2/d:
+			t -= 1.5 / d
+			return n*t*t + 0.75
+		case t < 2.5/d:
+			t -= 2.25 / d
+			return n*t*t + 0.9375
+		default:
+			t -= 2.625 / d
+			return n*t*t + 0.984375
+		}
+	})
+
+	// EaseInOutBounce defines the ease-in-out easing function for the Bounce function.
+	EaseInOutBounce = EaseInOut(EaseInBounce)
+
+	// EaseOutInBounce defines the ease-out-in easing function for the Bounce function.
+	EaseOutInBounce = EaseOutIn(EaseInBounce)
+
 	// Sine defines the easing using the sine wave function.
-	Sine = New(func(t, m float64) float64 { return 1 + math.Sin((math.Pi/2)*(t-(math.Pi/2))) })
+	Sine = New(func(t, m float64) float64 { return 1 - math.Cos(t*math.Pi/2) })
+
+	// EaseInSine defines the ease-in easing function for the Sine function.
+	EaseInSine = Sine
+
+	// EaseOutSine defines the ease-out easing function for the Sine function.
+	EaseOutSine = EaseOut(EaseInSine)
+
+	// EaseInOutSine defines the ease-in-out easing function for the Sine function.
+	EaseInOutSine = EaseInOut(EaseInSine)
+
+	// EaseOutInSine defines the ease-out-in easing function for the Sine function.
+	EaseOutInSine = EaseOutIn(EaseInSine)
 
 	// Circ defines the easing using the circ wave function.
-	Circ = New(func(t, m float64) float64 { return 1 + math.Sqrt(1-(t*t)) })
+	Circ = New(func(t, m float64) float64 { return 1 - math.Sqrt(1-(t*t)) })
+
+	// EaseInCirc defines the ease-in easing function for the Circ function.
+	EaseInCirc = Circ
+
+	// EaseOutCirc defines the ease-out easing function for the Circ function.
+	EaseOutCirc = EaseOut(EaseInCirc)
+
+	// EaseInOutCirc defines the ease-in-out easing function for the Circ function.
+	EaseInOutCirc = EaseInOut(EaseInCirc)
 
-	// Elastic defines the easing using the elastic wave function.
+	// EaseOutInCirc defines the ease-out-in easing function for the Circ function.
+	EaseOutInCirc = EaseOutIn(EaseInCirc)
+
+	// Elastic defines the easing using the elastic wave function, which
+	// oscillates with growing amplitude before reaching the end value. m sets
+	// the period, defaulting to 0.3 when zero.
 	Elastic = New(func(t, m float64) float64 {
 		if t == 1 || t == 0 {
 			return t
 		}
 
-		var p = (1 - math.Min(m, 998)) / 1000
-		var st = 1 / t
-		var st1 = st - 1
-		var s = p / ((2 * math.Pi) * math.Sinh(1))
-		return -1 * (math.Pow(2, (10*st1)) * math.Sin(((st1-s)*(2*math.Pi))/p))
+		if m == 0 {
+			m = 0.3
+		}
+
+		s := m / 4
+		t--
+		return -(math.Pow(2, 10*t) * math.Sin((t-s)*(2*math.Pi)/m))
 	})
+
+	// EaseInElastic defines the ease-in easing function for the Elastic function.
+	EaseInElastic = Elastic
+
+	// EaseOutElastic defines the ease-out easing function for the Elastic function.
+	EaseOutElastic = EaseOut(EaseInElastic)
+
+	// EaseInOutElastic defines the ease-in-out easing function for the Elastic function.
+	EaseInOutElastic = EaseInOut(EaseInElastic)
+
+	// EaseOutInElastic defines the ease-out-in easing function for the Elastic function.
+	EaseOutInElastic = EaseOutIn(EaseInElastic)
 )
 
 //==============================================================================
@@ -203,6 +301,36 @@ func New(handler EasingFunc) Easing {
 	}
 }
 
+// EaseOut returns the ease-out variant of the provided ease-in function,
+// which is it's reflection.
+func EaseOut(in Easing) Easing {
+	return New(func(t, m float64) float64 { return 1 - in.Ease(1-t, m) })
+}
+
+// EaseInOut returns a Easing which runs the provided ease-in function for
+// the first half and it's ease-out variant for the second.
+func EaseInOut(in Easing) Easing {
+	return New(func(t, m float64) float64 {
+		if t < 0.5 {
+			return in.Ease(t*2, m) / 2
+		}
+
+		return 1 - in.Ease((-2*t)+2, m)/2
+	})
+}
+
+// EaseOutIn returns a Easing which runs the ease-out variant of the provided
+// ease-in function for the first half and the function itself for the second.
+func EaseOutIn(in Easing) Easing {
+	return New(func(t, m float64) float64 {
+		if t < 0.5 {
+			return (1 - in.Ease(1-(2*t), m)) / 2
+		}
+
+		return (in.Ease((t*2)-1, m) + 1) / 2
+	})
+}
+
 //==============================================================================
 
 type easing struct {
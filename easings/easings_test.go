@@ -0,0 +1,124 @@
+package easings_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/influx6/faux/easings"
+)
+
+func TestEndpoints(t *testing.T) {
+	curves := map[string]easings.Easing{
+		"Linear":           easings.Linear,
+		"EaseInOutQuad":    easings.EaseInOutQuad,
+		"EaseOutCubic":     easings.EaseOutCubic,
+		"EaseInOutQuart":   easings.EaseInOutQuart,
+		"EaseOutInQuint":   easings.EaseOutInQuint,
+		"EaseInOutExpo":    easings.EaseInOutExpo,
+		"EaseInOutSine":    easings.EaseInOutSine,
+		"EaseInOutCirc":    easings.EaseInOutCirc,
+		"EaseInOutBack":    easings.EaseInOutBack,
+		"EaseOutElastic":   easings.EaseOutElastic,
+		"EaseInOutElastic": easings.EaseInOutElastic,
+		"EaseOutBounce":    easings.EaseOutBounce,
+		"EaseInOutBounce":  easings.EaseInOutBounce,
+		"CubicBezier":      easings.CubicBezier(0.42, 0, 0.58, 1),
+	}
+
+	for name, curve := range curves {
+		if v := curve.Ease(0, 0); math.Abs(v) > 1e-3 {
+			t.Fatalf("%s should start at 0: %f", name, v)
+		}
+
+		if v := curve.Ease(1, 0); math.Abs(v-1) > 1e-3 {
+			t.Fatalf("%s should end at 1: %f", name, v)
+		}
+	}
+}
+
+func TestCurves(t *testing.T) {
+	if v := easings.EaseOutBack.Ease(0.8, 0); v <= 1 {
+		t.Fatalf("EaseOutBack should overshoot: %f", v)
+	}
+
+	if v := easings.EaseInOutSine.Ease(0.5, 0); math.Abs(v-0.5) > 1e-9 {
+		t.Fatalf("EaseInOutSine should be symmetric: %f", v)
+	}
+
+	for i := 1; i < 20; i++ {
+		p := float64(i) / 20
+		if v := easings.EaseInCirc.Ease(p, 0); v < 0 || v > 1 {
+			t.Fatalf("EaseInCirc should stay within bounds at %f: %f", p, v)
+		}
+	}
+}
+
+func TestCubicBezier(t *testing.T) {
+	linear := easings.CubicBezier(0.25, 0.25, 0.75, 0.75)
+	if v := linear.Ease(0.3, 0); math.Abs(v-0.3) > 1e-3 {
+		t.Fatalf("Should follow linear curve: %f", v)
+	}
+
+	ease, ok := easings.Bezier("easeInOut")
+	if !ok {
+		t.Fatalf("Should have found named curve")
+	}
+
+	if v := ease.Ease(0.5, 0); math.Abs(v-0.5) > 1e-3 {
+		t.Fatalf("easeInOut should pass through midpoint: %f", v)
+	}
+
+	if v := ease.Ease(0.25, 0); math.Abs(v-0.129) > 1e-2 {
+		t.Fatalf("easeInOut should match css curve: %f", v)
+	}
+
+	if _, ok := easings.Bezier("unknown"); ok {
+		t.Fatalf("Should not have found unknown curve")
+	}
+}
+
+func TestCubicBezierEdges(t *testing.T) {
+	curves := map[string]easings.Easing{
+		"snap":      easings.CubicBezier(0, 0.5, 0, 1),
+		"slowStart": easings.CubicBezier(1, 0, 1, 0.5),
+		"steep":     easings.CubicBezier(0, 1, 1, 0),
+		"expo":      easings.CubicBezier(1, 0, 0, 1),
+	}
+
+	for name, curve := range curves {
+		last := 0.0
+		for i := 1; i < 100; i++ {
+			p := float64(i) / 100
+			v := curve.Ease(p, 0)
+			if v < 0 || v > 1 {
+				t.Fatalf("%s should stay within bounds at %f: %f", name, p, v)
+			}
+
+			if v < last-1e-6 {
+				t.Fatalf("%s should be monotonic at %f: %f < %f", name, p, v, last)
+			}
+			last = v
+		}
+	}
+
+	if v := curves["snap"].Ease(0.05, 0); v < 0.1 {
+		t.Fatalf("snap should rise quickly: %f", v)
+	}
+}
+
+func TestCubicBezierConcurrent(t *testing.T) {
+	curve := easings.CubicBezier(0.25, 0.25, 0.75, 0.75)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				curve.Ease(float64(j)/100, 0)
+			}
+		}()
+	}
+	wg.Wait()
+}
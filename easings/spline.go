@@ -1,5 +1,7 @@
 package easings
 
+import "math"
+
 // PropertyCurves provides a interface for easing values using curves data
 // that provide values at different time areas. Thet allow us to provided
 // animation behaviours for objects using curve data. Similar to animation
@@ -22,6 +24,7 @@ type Spline struct {
 // NewSpline returns a new spline set with the specific control points.
 func NewSpline(x, y, x2, y2 float64) *Spline {
 	ss := Spline{x1: x, y1: y, x2: x2, y2: y2}
+	ss.optimize = x == y && x2 == y2
 	return &ss
 }
 
@@ -37,35 +40,13 @@ func (s *Spline) X(t float64) float64 {
 		return t
 	}
 
-	if s.x1 == s.y1 && s.x2 == s.y2 {
-		s.optimize = true
-		return t
-	}
-
 	return CalculateBezier(s.GetTimeForX(t), s.y1, s.y2)
 }
 
 // GetTimeForX returns the giving time value between 0 and 1 for the provided
 // x coordinate for a bezier curve.
 func (s *Spline) GetTimeForX(aX float64) float64 {
-	// Newton raphson iteration
-	aGuessT := aX
-
-	for i := 0; i < 4; i++ {
-
-		currentSlope := GetSlope(aGuessT, s.x1, s.y1)
-
-		if currentSlope == 0.0 {
-			return aGuessT
-		}
-
-		currentX := CalculateBezier(aGuessT, s.x1, s.x2) - aX
-
-		aGuessT -= currentX / currentSlope
-
-	}
-
-	return aGuessT
+	return solveBezier(aX, s.x1, s.x2)
 }
 
 // Y returns the provided x value for a giving time between 0 and 1.
@@ -74,32 +55,59 @@ func (s *Spline) Y(t float64) float64 {
 		return t
 	}
 
-	if s.x1 == s.y1 && s.x2 == s.y2 {
-		s.optimize = true
-		return t
-	}
-
 	return CalculateBezier(s.GetTimeForX(t), s.x1, s.x2)
 }
 
 // GetTimeForY returns the giving time value between 0 and 1 for the provided
 // y coordinate for a bezier curve.
 func (s *Spline) GetTimeForY(aY float64) float64 {
-	// Newton raphson iteration
-	aGuessT := aY
+	return solveBezier(aY, s.y1, s.y2)
+}
 
-	for i := 0; i < 4; i++ {
+// solveBezier returns the time between 0 and 1 at which the bezier curve with
+// the giving control points reaches the value aX. It uses newton raphson
+// iteration, falling back to bisection when the slope is too flat or the
+// guess leaves the curve, as the WebKit implementation does.
+func solveBezier(aX, aA1, aA2 float64) float64 {
+	const epsilon = 1e-7
 
-		currentSlope := GetSlope(aGuessT, s.y1, s.y2)
+	if aX <= 0 || aX >= 1 {
+		return aX
+	}
 
-		if currentSlope == 0.0 {
+	aGuessT := aX
+	for i := 0; i < 8; i++ {
+		currentX := CalculateBezier(aGuessT, aA1, aA2) - aX
+		if math.Abs(currentX) < epsilon {
 			return aGuessT
 		}
 
-		currentX := CalculateBezier(aGuessT, s.y1, s.y1) - aY
+		currentSlope := GetSlope(aGuessT, aA1, aA2)
+		if math.Abs(currentSlope) < 1e-6 {
+			break
+		}
 
 		aGuessT -= currentX / currentSlope
+		if aGuessT < 0 || aGuessT > 1 {
+			break
+		}
+	}
 
+	lower, upper := 0.0, 1.0
+	aGuessT = aX
+	for i := 0; i < 64 && upper-lower > epsilon; i++ {
+		currentX := CalculateBezier(aGuessT, aA1, aA2)
+		if math.Abs(currentX-aX) < epsilon {
+			return aGuessT
+		}
+
+		if currentX < aX {
+			lower = aGuessT
+		} else {
+			upper = aGuessT
+		}
+
+		aGuessT = (lower + upper) / 2
 	}
 
 	return aGuessT
@@ -107,6 +115,30 @@ func (s *Spline) GetTimeForY(aY float64) float64 {
 
 //==============================================================================
 
+// CubicBezier returns a Easing which follows the cubic bezier curve with the
+// provided control points, as the css cubic-bezier function does.
+func CubicBezier(x1, y1, x2, y2 float64) Easing {
+	spline := NewSpline(x1, y1, x2, y2)
+	return New(func(t, m float64) float64 {
+		if t <= 0 || t >= 1 {
+			return t
+		}
+		return spline.X(t)
+	})
+}
+
+// Bezier returns the CubicBezier Easing for the giving name within
+// EasingValues, such as "easeInOutQuad".
+func Bezier(name string) (Easing, bool) {
+	points, ok := EasingValues[name]
+	if !ok || len(points) != 4 {
+		return nil, false
+	}
+	return CubicBezier(points[0], points[1], points[2], points[3]), true
+}
+
+//==============================================================================
+
 // GetSlope returns dx/dt given t, x1, and x2, or dy/dt given t, y1, and y2.
 func GetSlope(aT, aA1, aA2 float64) float64 {
 	return 3.0*a(aA1, aA2)*aT*aT + 2.0*b(aA1, aA2)*aT + c(aA1)
@@ -0,0 +1,299 @@
+// Package errs provides errors which carry a stack trace, key-value fields
+// and an error code, wrap other errors while staying compatible with the
+// standard errors.Is and errors.As, and convert directly into metrics
+// entries.
+package errs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+
+	"github.com/influx6/faux/metrics"
+)
+
+// maxDepth sets the maximum number of stack frames captured.
+const maxDepth = 32
+
+// Frame defines a single frame of a captured stack.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// String returns the frame as "function file:line".
+func (f Frame) String() string {
+	return fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+}
+
+// Error defines an error with a message, code, fields, the stack where it was
+// created and an optional cause it wraps.
+type Error struct {
+	Message string
+	Code    string
+	Fields  metrics.Field
+	cause   error
+	stack   []uintptr
+}
+
+// New returns a new Error with the giving message and key-value pairs, i.e
+// New("user not found", "id", 20).
+func New(message string, kv ...interface{}) error {
+	return &Error{
+		Message: message,
+		Fields:  fields(kv),
+		stack:   callers(),
+	}
+}
+
+// Errorf returns a new Error with the formatted message.
+func Errorf(format string, args ...interface{}) error {
+	return &Error{
+		Message: fmt.Sprintf(format, args...),
+		stack:   callers(),
+	}
+}
+
+// Wrap returns a new Error which wraps err with the giving message and
+// key-value pairs. It returns nil if err is nil. The stack of err is reused
+// if it already carries one.
+func Wrap(err error, message string, kv ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	e := &Error{
+		Message: message,
+		Fields:  fields(kv),
+		cause:   err,
+	}
+
+	var inner *Error
+	if !errors.As(err, &inner) {
+		e.stack = callers()
+	}
+
+	return e
+}
+
+// WithCode returns an Error wrapping err which carries the giving code. It
+// returns nil if err is nil.
+func WithCode(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+
+	if e, ok := err.(*Error); ok && e.Code == "" {
+		c := *e
+		c.Code = code
+		return &c
+	}
+
+	e := &Error{Code: code, cause: err}
+	var inner *Error
+	if !errors.As(err, &inner) {
+		e.stack = callers()
+	}
+	return e
+}
+
+// Error implements the error interface, joining the messages of the chain.
+func (e *Error) Error() string {
+	switch {
+	case e.cause == nil:
+		return e.Message
+	case e.Message == "":
+		return e.cause.Error()
+	}
+	return e.Message + ": " + e.cause.Error()
+}
+
+// Unwrap returns the error wrapped by e, if any.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is returns true if target is an *Error with the same non-empty code,
+// allowing sentinel errors to be declared by code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+// Format implements fmt.Formatter, where %+v prints the stack below the
+// message.
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		io.WriteString(s, e.Error())
+		if s.Flag('+') {
+			for _, frame := range Stack(e) {
+				io.WriteString(s, "\n\t")
+				io.WriteString(s, frame.String())
+			}
+		}
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+//==============================================================================
+
+// Is reports whether any error in err's chain matches target. It is the same
+// as errors.Is.
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+// As finds the first error in err's chain that matches target. It is the same
+// as errors.As.
+func As(err error, target interface{}) bool {
+	return errors.As(err, target)
+}
+
+// Code returns the first non-empty code in err's chain.
+func Code(err error) string {
+	for err != nil {
+		if e, ok := err.(*Error); ok && e.Code != "" {
+			return e.Code
+		}
+		err = errors.Unwrap(err)
+	}
+	return ""
+}
+
+// Fields returns the fields of all errors in err's chain, where fields of
+// outer errors take precedence over inner ones.
+func Fields(err error) metrics.Field {
+	var chain []*Error
+	for err != nil {
+		if e, ok := err.(*Error); ok {
+			chain = append(chain, e)
+		}
+		err = errors.Unwrap(err)
+	}
+
+	merged := make(metrics.Field)
+	for index := len(chain) - 1; index >= 0; index-- {
+		for key, value := range chain[index].Fields {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// Stack returns the stack captured by the innermost Error in err's chain.
+func Stack(err error) []Frame {
+	var stack []uintptr
+	for err != nil {
+		if e, ok := err.(*Error); ok && e.stack != nil {
+			stack = e.stack
+		}
+		err = errors.Unwrap(err)
+	}
+
+	if len(stack) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(stack)
+	list := make([]Frame, 0, len(stack))
+	for {
+		frame, more := frames.Next()
+		list = append(list, Frame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		})
+
+		if !more {
+			break
+		}
+	}
+	return list
+}
+
+//==============================================================================
+
+// Metric returns a metrics.EntryMod which sets the entry to an error entry
+// for err, with the fields and code of err's chain added to the entry
+// fields, the location of the error as the entry's function, file and line
+// and the stack as it's trace.
+func Metric(err error) metrics.EntryMod {
+	return func(en *metrics.Entry) {
+		metrics.Error(err)(en)
+
+		for key, value := range Fields(err) {
+			en.Field[key] = value
+		}
+
+		if code := Code(err); code != "" {
+			en.Field["code"] = code
+		}
+
+		stack := Stack(err)
+		if len(stack) == 0 {
+			return
+		}
+
+		en.Function, en.File, en.Line = stack[0].Function, stack[0].File, stack[0].Line
+
+		var bu bytes.Buffer
+		for _, frame := range stack {
+			bu.WriteString(frame.String())
+			bu.WriteString("\n")
+		}
+
+		en.Trace = metrics.Trace{
+			Function:   stack[0].Function,
+			File:       stack[0].File,
+			LineNumber: stack[0].Line,
+			Stack:      bu.Bytes(),
+		}
+	}
+}
+
+// Entry returns a metrics.Entry for err, as produced by Metric.
+func Entry(err error) metrics.Entry {
+	var en metrics.Entry
+	metrics.Apply(&en, Metric(err))
+	return en
+}
+
+//==============================================================================
+
+// callers returns the stack of the caller of the function calling it.
+func callers() []uintptr {
+	pcs := make([]uintptr, maxDepth)
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// fields returns the metrics.Field of the giving key-value pairs, where keys
+// are formatted as strings and a missing last value is set to nil.
+func fields(kv []interface{}) metrics.Field {
+	if len(kv) == 0 {
+		return nil
+	}
+
+	f := make(metrics.Field, len(kv)/2+1)
+	for index := 0; index < len(kv); index += 2 {
+		key, ok := kv[index].(string)
+		if !ok {
+			key = strings.TrimSpace(fmt.Sprint(kv[index]))
+		}
+
+		if index+1 < len(kv) {
+			f[key] = kv[index+1]
+			continue
+		}
+		f[key] = nil
+	}
+	return f
+}
@@ -0,0 +1,88 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/influx6/faux/errs"
+	"github.com/influx6/faux/metrics"
+)
+
+var errNotFound = &errs.Error{Code: "not_found", Message: "not found"}
+
+func TestWrap(t *testing.T) {
+	base := errs.New("user missing", "id", 20)
+	err := errs.Wrap(base, "load profile", "handler", "profile")
+
+	if err.Error() != "load profile: user missing" {
+		t.Fatalf("Should have joined messages of chain: %q", err.Error())
+	}
+
+	if !errs.Is(err, base) {
+		t.Fatalf("Should have matched wrapped error")
+	}
+
+	fields := errs.Fields(err)
+	if fields["id"] != 20 || fields["handler"] != "profile" {
+		t.Fatalf("Should have merged fields of chain: %+v", fields)
+	}
+
+	stack := errs.Stack(err)
+	if len(stack) == 0 || !strings.Contains(stack[0].Function, "TestWrap") {
+		t.Fatalf("Should have captured stack at creation: %+v", stack)
+	}
+
+	if errs.Wrap(nil, "nothing") != nil {
+		t.Fatalf("Should have returned nil when wrapping nil")
+	}
+
+	wrapped := errs.Wrap(io.EOF, "read body")
+	if !errors.Is(wrapped, io.EOF) || len(errs.Stack(wrapped)) == 0 {
+		t.Fatalf("Should have wrapped standard error with stack")
+	}
+
+	if !strings.Contains(fmt.Sprintf("%+v", wrapped), "TestWrap") {
+		t.Fatalf("Should have printed stack with %%+v: %+v", wrapped)
+	}
+}
+
+func TestCode(t *testing.T) {
+	err := errs.Wrap(errs.WithCode(io.EOF, "not_found"), "lookup user")
+
+	if errs.Code(err) != "not_found" {
+		t.Fatalf("Should have found code in chain: %q", errs.Code(err))
+	}
+
+	if !errs.Is(err, errNotFound) {
+		t.Fatalf("Should have matched sentinel by code")
+	}
+
+	if errs.Is(errs.WithCode(io.EOF, "denied"), errNotFound) {
+		t.Fatalf("Should not have matched sentinel with other code")
+	}
+
+	var e *errs.Error
+	if !errs.As(err, &e) || e.Message != "lookup user" {
+		t.Fatalf("Should have found Error in chain: %+v", e)
+	}
+}
+
+func TestEntry(t *testing.T) {
+	err := errs.WithCode(errs.New("payment failed", "amount", 40), "payment")
+
+	en := errs.Entry(err)
+	if en.Level != metrics.ErrorLvl || en.Message != "payment failed" {
+		t.Fatalf("Should have created error entry: %+v", en)
+	}
+
+	if en.Field["amount"] != 40 || en.Field["code"] != "payment" || en.Field["error"] != err {
+		t.Fatalf("Should have added fields and code to entry: %+v", en.Field)
+	}
+
+	if !strings.Contains(en.Function, "TestEntry") || len(en.Trace.Stack) == 0 {
+		t.Fatalf("Should have set location and trace of error: %q", en.Function)
+	}
+}
@@ -0,0 +1,367 @@
+// Package eventbus provides an application event bus where topics are
+// registered with a payload contract, and subscribers attach to topics or
+// wildcard patterns with synchronous or asynchronous delivery.
+//
+// Topics are dot separated names, i.e "user.created". Patterns may use "*" to
+// match a single segment ("user.*") and "**" as the last segment to match any
+// remaining segments ("metrics.**").
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/influx6/faux/panics"
+)
+
+// errors.
+var (
+	ErrTopicExists     = errors.New("topic already registered")
+	ErrUnknownTopic    = errors.New("topic not registered")
+	ErrInvalidTopic    = errors.New("topic must be non-empty and not contain wildcards")
+	ErrInvalidHandler  = errors.New("handler must be func(Event), func(T) or func(string, T)")
+	ErrHandlerMismatch = errors.New("handler argument type does not match topic payload type")
+)
+
+// Event defines a payload published on a giving topic.
+type Event struct {
+	Topic   string
+	Payload interface{}
+}
+
+// TopicInfo defines the details of a registered topic.
+type TopicInfo struct {
+	Name        string
+	Type        reflect.Type
+	Subscribers int
+}
+
+// topic holds a registered topic and it's payload type, where a nil type
+// accepts any payload.
+type topic struct {
+	name string
+	typ  reflect.Type
+}
+
+// Bus defines an event bus which delivers published events to subscribers
+// of matching topics.
+type Bus struct {
+	ml      sync.RWMutex
+	topics  map[string]*topic
+	subs    []*Subscription
+	pending sync.WaitGroup
+	onError func(Event, error)
+}
+
+// New returns a new instance of a Bus. The provided function, if not nil,
+// receives errors and panics raised by handlers.
+func New(onError func(Event, error)) *Bus {
+	return &Bus{
+		topics:  make(map[string]*topic),
+		onError: onError,
+	}
+}
+
+// Register adds a topic into the bus whose payloads must be assignable to the
+// type of the giving sample value. A nil sample accepts any payload, and an
+// interface contract can be declared with a nil pointer to the interface,
+// i.e (*error)(nil). It returns ErrHandlerMismatch if an existing subscriber
+// of a matching pattern can not take the payload type.
+func (b *Bus) Register(name string, sample interface{}) error {
+	if name == "" || strings.Contains(name, "*") {
+		return ErrInvalidTopic
+	}
+
+	var typ reflect.Type
+	if sample != nil {
+		typ = reflect.TypeOf(sample)
+		if typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Interface {
+			typ = typ.Elem()
+		}
+	}
+
+	b.ml.Lock()
+	defer b.ml.Unlock()
+
+	if _, ok := b.topics[name]; ok {
+		return ErrTopicExists
+	}
+
+	for _, sub := range b.subs {
+		if sub.argType != nil && sub.matcher.match(name) && !compatible(typ, sub.argType) {
+			return fmt.Errorf("%w: topic %q carries %s, handler of %q takes %s", ErrHandlerMismatch, name, typeName(typ), sub.pattern, sub.argType)
+		}
+	}
+
+	b.topics[name] = &topic{name: name, typ: typ}
+	return nil
+}
+
+// MustRegister calls Register, panicking if it fails.
+func (b *Bus) MustRegister(name string, sample interface{}) {
+	if err := b.Register(name, sample); err != nil {
+		panic(err)
+	}
+}
+
+// Topics returns the details of all registered topics sorted by name.
+func (b *Bus) Topics() []TopicInfo {
+	b.ml.RLock()
+	defer b.ml.RUnlock()
+
+	infos := make([]TopicInfo, 0, len(b.topics))
+	for _, tp := range b.topics {
+		info := TopicInfo{Name: tp.name, Type: tp.typ}
+		for _, sub := range b.subs {
+			if sub.matcher.match(tp.name) {
+				info.Subscribers++
+			}
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos
+}
+
+// Subscribe attaches the giving handler to all topics matching the pattern.
+// The handler can be a func(Event), a func(T) or a func(string, T), where T
+// must be compatible with the payload type of all matching registered
+// topics. Delivery is synchronous unless the Async option is provided.
+func (b *Bus) Subscribe(pattern string, handler interface{}, opts ...Option) (*Subscription, error) {
+	fn, argType, err := adapt(handler)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &Subscription{
+		bus:     b,
+		pattern: pattern,
+		matcher: compile(pattern),
+		argType: argType,
+		fn:      fn,
+	}
+
+	for _, opt := range opts {
+		opt(sub)
+	}
+
+	b.ml.Lock()
+	defer b.ml.Unlock()
+
+	if argType != nil {
+		for _, tp := range b.topics {
+			if sub.matcher.match(tp.name) && !compatible(tp.typ, argType) {
+				return nil, fmt.Errorf("%w: topic %q carries %s, handler takes %s", ErrHandlerMismatch, tp.name, typeName(tp.typ), argType)
+			}
+		}
+	}
+
+	b.subs = append(b.subs, sub)
+	return sub, nil
+}
+
+// Publish delivers the payload to all subscribers of the giving registered
+// topic. Synchronous subscribers are called before Publish returns.
+func (b *Bus) Publish(name string, payload interface{}) error {
+	b.ml.RLock()
+	tp, ok := b.topics[name]
+	if !ok {
+		b.ml.RUnlock()
+		return ErrUnknownTopic
+	}
+
+	if tp.typ != nil && (payload == nil || !reflect.TypeOf(payload).AssignableTo(tp.typ)) {
+		b.ml.RUnlock()
+		return fmt.Errorf("%w: topic %q expects %s, got %T", ErrHandlerMismatch, name, tp.typ, payload)
+	}
+
+	var subs []*Subscription
+	for _, sub := range b.subs {
+		if sub.matcher.match(name) {
+			subs = append(subs, sub)
+		}
+	}
+	b.ml.RUnlock()
+
+	ev := Event{Topic: name, Payload: payload}
+	for _, sub := range subs {
+		if sub.argType != nil && (payload == nil || !reflect.TypeOf(payload).AssignableTo(sub.argType)) {
+			continue
+		}
+
+		if sub.async {
+			b.pending.Add(1)
+			go func(sub *Subscription) {
+				defer b.pending.Done()
+				b.deliver(sub, ev)
+			}(sub)
+			continue
+		}
+
+		b.deliver(sub, ev)
+	}
+
+	return nil
+}
+
+// Wait blocks until all in-flight asynchronous deliveries are done.
+func (b *Bus) Wait() {
+	b.pending.Wait()
+}
+
+// deliver calls the subscription handler, reporting panics as errors.
+func (b *Bus) deliver(sub *Subscription, ev Event) {
+	err := panics.Guard(func() error {
+		sub.fn(ev)
+		return nil
+	})
+
+	if err != nil && b.onError != nil {
+		b.onError(ev, err)
+	}
+}
+
+// remove detaches the giving subscription from the bus.
+func (b *Bus) remove(sub *Subscription) {
+	b.ml.Lock()
+	defer b.ml.Unlock()
+
+	for index, item := range b.subs {
+		if item == sub {
+			b.subs = append(b.subs[:index], b.subs[index+1:]...)
+			return
+		}
+	}
+}
+
+//==============================================================================
+
+// Option defines a function type which configures a Subscription.
+type Option func(*Subscription)
+
+// Async delivers events to the subscriber on a new goroutine.
+func Async() Option {
+	return func(s *Subscription) {
+		s.async = true
+	}
+}
+
+// Subscription defines a handler attached to a topic pattern.
+type Subscription struct {
+	bus     *Bus
+	pattern string
+	matcher matcher
+	argType reflect.Type
+	async   bool
+	fn      func(Event)
+}
+
+// Pattern returns the topic pattern of the subscription.
+func (s *Subscription) Pattern() string {
+	return s.pattern
+}
+
+// Unsubscribe detaches the subscription from it's bus.
+func (s *Subscription) Unsubscribe() {
+	s.bus.remove(s)
+}
+
+//==============================================================================
+
+// adapt converts the giving handler into a func(Event), returning the type
+// of the payload argument it expects, or nil for func(Event).
+func adapt(handler interface{}) (func(Event), reflect.Type, error) {
+	if fn, ok := handler.(func(Event)); ok {
+		return fn, nil, nil
+	}
+
+	fval := reflect.ValueOf(handler)
+	if fval.Kind() != reflect.Func || fval.Type().NumOut() != 0 {
+		return nil, nil, ErrInvalidHandler
+	}
+
+	ftype := fval.Type()
+	switch ftype.NumIn() {
+	case 1:
+		return func(ev Event) {
+			fval.Call([]reflect.Value{payloadValue(ev.Payload, ftype.In(0))})
+		}, ftype.In(0), nil
+	case 2:
+		if ftype.In(0).Kind() != reflect.String {
+			return nil, nil, ErrInvalidHandler
+		}
+
+		return func(ev Event) {
+			fval.Call([]reflect.Value{reflect.ValueOf(ev.Topic), payloadValue(ev.Payload, ftype.In(1))})
+		}, ftype.In(1), nil
+	}
+
+	return nil, nil, ErrInvalidHandler
+}
+
+// payloadValue returns the reflect.Value of the payload as the giving type.
+func payloadValue(payload interface{}, typ reflect.Type) reflect.Value {
+	if payload == nil {
+		return reflect.Zero(typ)
+	}
+	return reflect.ValueOf(payload)
+}
+
+// compatible returns true if payloads of the topic type can be passed to a
+// handler argument of the giving type. Topics accepting any payload are
+// compatible with all handlers, which then only receive assignable payloads.
+func compatible(topicType reflect.Type, argType reflect.Type) bool {
+	if topicType == nil {
+		return true
+	}
+
+	return topicType.AssignableTo(argType) || (topicType.Kind() == reflect.Interface && argType.Implements(topicType))
+}
+
+func typeName(typ reflect.Type) string {
+	if typ == nil {
+		return "interface {}"
+	}
+	return typ.String()
+}
+
+//==============================================================================
+
+// matcher matches topic names against a compiled pattern.
+type matcher struct {
+	segments []string
+}
+
+// compile returns a matcher for the giving pattern.
+func compile(pattern string) matcher {
+	return matcher{segments: strings.Split(pattern, ".")}
+}
+
+// match returns true if the topic name matches the pattern.
+func (m matcher) match(name string) bool {
+	parts := strings.Split(name, ".")
+
+	for index, segment := range m.segments {
+		if segment == "**" && index == len(m.segments)-1 {
+			return len(parts) > index
+		}
+
+		if index >= len(parts) {
+			return false
+		}
+
+		if segment != "*" && segment != parts[index] {
+			return false
+		}
+	}
+
+	return len(parts) == len(m.segments)
+}
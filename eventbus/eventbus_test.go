@@ -0,0 +1,147 @@
+package eventbus_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/influx6/faux/eventbus"
+)
+
+type userCreated struct {
+	Name string
+}
+
+func TestTypedSubscription(t *testing.T) {
+	bus := eventbus.New(nil)
+	bus.MustRegister("user.created", userCreated{})
+
+	var received []string
+	if _, err := bus.Subscribe("user.created", func(ev userCreated) {
+		received = append(received, ev.Name)
+	}); err != nil {
+		t.Fatalf("Should have subscribed typed handler: %+v", err)
+	}
+
+	if _, err := bus.Subscribe("user.created", func(count int) {}); !errors.Is(err, eventbus.ErrHandlerMismatch) {
+		t.Fatalf("Should have rejected handler with mismatched payload type: %+v", err)
+	}
+
+	if err := bus.Publish("user.created", userCreated{Name: "bob"}); err != nil {
+		t.Fatalf("Should have published event: %+v", err)
+	}
+
+	if err := bus.Publish("user.created", "bob"); !errors.Is(err, eventbus.ErrHandlerMismatch) {
+		t.Fatalf("Should have rejected payload with mismatched type: %+v", err)
+	}
+
+	if err := bus.Publish("user.deleted", userCreated{}); err != eventbus.ErrUnknownTopic {
+		t.Fatalf("Should have rejected unregistered topic: %+v", err)
+	}
+
+	if len(received) != 1 || received[0] != "bob" {
+		t.Fatalf("Should have received single event: %+v", received)
+	}
+}
+
+func TestRegisterAfterSubscribe(t *testing.T) {
+	bus := eventbus.New(nil)
+
+	if _, err := bus.Subscribe("user.*", func(ev userCreated) {}); err != nil {
+		t.Fatalf("Should have subscribed typed handler: %+v", err)
+	}
+
+	if err := bus.Register("user.created", userCreated{}); err != nil {
+		t.Fatalf("Should have registered compatible topic: %+v", err)
+	}
+
+	if err := bus.Register("user.deleted", 0); !errors.Is(err, eventbus.ErrHandlerMismatch) {
+		t.Fatalf("Should have rejected topic incompatible with existing handler: %+v", err)
+	}
+
+	if err := bus.Register("metrics.cpu", 0); err != nil {
+		t.Fatalf("Should have registered topic without matching handlers: %+v", err)
+	}
+}
+
+func TestWildcardSubscription(t *testing.T) {
+	bus := eventbus.New(nil)
+	bus.MustRegister("user.created", userCreated{})
+	bus.MustRegister("user.session.opened", nil)
+	bus.MustRegister("metrics.cpu", 0)
+
+	var single, all []string
+
+	bus.Subscribe("user.*", func(ev eventbus.Event) {
+		single = append(single, ev.Topic)
+	})
+
+	bus.Subscribe("user.**", func(topic string, payload interface{}) {
+		all = append(all, topic)
+	})
+
+	bus.Publish("user.created", userCreated{})
+	bus.Publish("user.session.opened", 1)
+	bus.Publish("metrics.cpu", 20)
+
+	if len(single) != 1 || single[0] != "user.created" {
+		t.Fatalf("Should have matched only single segment topics: %+v", single)
+	}
+
+	if len(all) != 2 {
+		t.Fatalf("Should have matched all nested topics: %+v", all)
+	}
+
+	infos := bus.Topics()
+	if len(infos) != 3 || infos[0].Name != "metrics.cpu" {
+		t.Fatalf("Should have listed topics sorted by name: %+v", infos)
+	}
+
+	if infos[0].Subscribers != 0 || infos[1].Subscribers != 2 || infos[2].Subscribers != 1 {
+		t.Fatalf("Should have counted subscribers per topic: %+v", infos)
+	}
+}
+
+func TestAsyncAndUnsubscribe(t *testing.T) {
+	var failures int32
+	bus := eventbus.New(func(ev eventbus.Event, err error) {
+		atomic.AddInt32(&failures, 1)
+	})
+	bus.MustRegister("jobs.done", (*error)(nil))
+
+	var count int32
+	sub, err := bus.Subscribe("jobs.done", func(err error) {
+		atomic.AddInt32(&count, 1)
+	}, eventbus.Async())
+	if err != nil {
+		t.Fatalf("Should have subscribed interface handler: %+v", err)
+	}
+
+	bus.Subscribe("jobs.done", func(eventbus.Event) {
+		panic("bad handler")
+	})
+
+	for i := 0; i < 10; i++ {
+		if err := bus.Publish("jobs.done", errors.New("done")); err != nil {
+			t.Fatalf("Should have published event: %+v", err)
+		}
+	}
+
+	bus.Wait()
+
+	if atomic.LoadInt32(&count) != 10 {
+		t.Fatalf("Should have delivered all async events: %d", count)
+	}
+
+	if atomic.LoadInt32(&failures) != 10 {
+		t.Fatalf("Should have reported all handler panics: %d", failures)
+	}
+
+	sub.Unsubscribe()
+	bus.Publish("jobs.done", errors.New("done"))
+	bus.Wait()
+
+	if atomic.LoadInt32(&count) != 10 {
+		t.Fatalf("Should not have delivered after unsubscribe: %d", count)
+	}
+}
@@ -0,0 +1,229 @@
+// Package fsm provides a finite state machine built from a declarative
+// definition of states and transitions, with guard conditions, entry and
+// exit actions and listeners notified of every state change.
+package fsm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// errors.
+var (
+	ErrNoInitialState   = errors.New("initial state not defined")
+	ErrUnknownState     = errors.New("state not defined")
+	ErrDuplicateState   = errors.New("state defined more than once")
+	ErrUnknownEvent     = errors.New("event not defined for current state")
+	ErrGuardRejected    = errors.New("transition rejected by guard")
+	ErrConflictingEvent = errors.New("event defined more than once for a state")
+)
+
+// Change defines a single transition of a machine from one state to another
+// caused by an event with it's associated data.
+type Change struct {
+	Event string
+	From  string
+	To    string
+	Data  interface{}
+}
+
+// Action defines a function type called when a state is entered or exited.
+type Action func(Change)
+
+// Guard defines a function type which decides if a transition may happen.
+type Guard func(Change) bool
+
+// Listener defines a function type which is notified after every completed
+// transition.
+type Listener func(Change)
+
+// State defines a single state of a machine with it's entry and exit actions.
+type State struct {
+	Name    string
+	OnEnter Action
+	OnExit  Action
+}
+
+// Transition defines a move into the To state when the Event is fired while
+// the machine is in any of the From states. An empty From applies the
+// transition to all states.
+type Transition struct {
+	Event string
+	From  []string
+	To    string
+	Guard Guard
+}
+
+// Definition defines the states and transitions of a machine.
+type Definition struct {
+	Initial     string
+	States      []State
+	Transitions []Transition
+}
+
+// Machine defines a finite state machine, where transitions are serialized.
+// Actions, guards and listeners may query the machine, but must not call
+// Fire on the same machine as it waits for the running transition.
+type Machine struct {
+	fml       sync.Mutex
+	ml        sync.Mutex
+	current   string
+	states    map[string]State
+	events    map[string]map[string]Transition
+	listeners []Listener
+}
+
+// New returns a new instance of a Machine in the initial state of the
+// definition, after validating that all referenced states exist. The
+// initial state's entry action is not called.
+func New(def Definition, listeners ...Listener) (*Machine, error) {
+	m := &Machine{
+		current:   def.Initial,
+		states:    make(map[string]State, len(def.States)),
+		events:    make(map[string]map[string]Transition),
+		listeners: listeners,
+	}
+
+	for _, state := range def.States {
+		if _, ok := m.states[state.Name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateState, state.Name)
+		}
+		m.states[state.Name] = state
+	}
+
+	if def.Initial == "" {
+		return nil, ErrNoInitialState
+	}
+
+	if _, ok := m.states[def.Initial]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownState, def.Initial)
+	}
+
+	for _, tr := range def.Transitions {
+		if _, ok := m.states[tr.To]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownState, tr.To)
+		}
+
+		from := tr.From
+		if len(from) == 0 {
+			for _, state := range def.States {
+				from = append(from, state.Name)
+			}
+		}
+
+		for _, name := range from {
+			if _, ok := m.states[name]; !ok {
+				return nil, fmt.Errorf("%w: %q", ErrUnknownState, name)
+			}
+
+			events, ok := m.events[name]
+			if !ok {
+				events = make(map[string]Transition)
+				m.events[name] = events
+			}
+
+			if _, ok := events[tr.Event]; ok {
+				return nil, fmt.Errorf("%w: %q in %q", ErrConflictingEvent, tr.Event, name)
+			}
+
+			events[tr.Event] = tr
+		}
+	}
+
+	return m, nil
+}
+
+// MustNew calls New, panicking if the definition is invalid.
+func MustNew(def Definition, listeners ...Listener) *Machine {
+	m, err := New(def, listeners...)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Current returns the current state of the machine.
+func (m *Machine) Current() string {
+	m.ml.Lock()
+	defer m.ml.Unlock()
+	return m.current
+}
+
+// Is returns true if the machine is in the giving state.
+func (m *Machine) Is(state string) bool {
+	return m.Current() == state
+}
+
+// Can returns true if the event is defined for the current state. Guards are
+// not evaluated.
+func (m *Machine) Can(event string) bool {
+	m.ml.Lock()
+	defer m.ml.Unlock()
+
+	_, ok := m.events[m.current][event]
+	return ok
+}
+
+// Events returns the sorted names of all events defined for the current
+// state.
+func (m *Machine) Events() []string {
+	m.ml.Lock()
+	defer m.ml.Unlock()
+
+	events := make([]string, 0, len(m.events[m.current]))
+	for event := range m.events[m.current] {
+		events = append(events, event)
+	}
+
+	sort.Strings(events)
+	return events
+}
+
+// Fire moves the machine into the target state of the event, calling the
+// exit action of the current state, the entry action of the target state and
+// then all listeners. It returns ErrUnknownEvent if the event is not defined
+// for the current state and ErrGuardRejected if the guard refused it.
+func (m *Machine) Fire(event string, data interface{}) error {
+	m.fml.Lock()
+	defer m.fml.Unlock()
+
+	m.ml.Lock()
+	current := m.current
+	tr, ok := m.events[current][event]
+	m.ml.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %q in %q", ErrUnknownEvent, event, current)
+	}
+
+	change := Change{
+		Event: event,
+		From:  current,
+		To:    tr.To,
+		Data:  data,
+	}
+
+	if tr.Guard != nil && !tr.Guard(change) {
+		return ErrGuardRejected
+	}
+
+	if exit := m.states[change.From].OnExit; exit != nil {
+		exit(change)
+	}
+
+	m.ml.Lock()
+	m.current = change.To
+	m.ml.Unlock()
+
+	if enter := m.states[change.To].OnEnter; enter != nil {
+		enter(change)
+	}
+
+	for _, listener := range m.listeners {
+		listener(change)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package fsm_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/influx6/faux/fsm"
+)
+
+func TestMachine(t *testing.T) {
+	var trail []string
+	var changes []fsm.Change
+
+	record := func(prefix string) fsm.Action {
+		return func(c fsm.Change) {
+			trail = append(trail, prefix+":"+c.Event)
+		}
+	}
+
+	m, err := fsm.New(fsm.Definition{
+		Initial: "idle",
+		States: []fsm.State{
+			{Name: "idle", OnExit: record("exit-idle")},
+			{Name: "running", OnEnter: record("enter-running"), OnExit: record("exit-running")},
+			{Name: "stopped", OnEnter: record("enter-stopped")},
+		},
+		Transitions: []fsm.Transition{
+			{Event: "start", From: []string{"idle"}, To: "running", Guard: func(c fsm.Change) bool {
+				return c.Data == "go"
+			}},
+			{Event: "stop", From: []string{"idle", "running"}, To: "stopped"},
+			{Event: "reset", To: "idle"},
+		},
+	}, func(c fsm.Change) {
+		changes = append(changes, c)
+	})
+	if err != nil {
+		t.Fatalf("Should have created machine: %+v", err)
+	}
+
+	if !reflect.DeepEqual(m.Events(), []string{"reset", "start", "stop"}) {
+		t.Fatalf("Should have listed events of idle state: %+v", m.Events())
+	}
+
+	if err := m.Fire("start", "wait"); err != fsm.ErrGuardRejected {
+		t.Fatalf("Should have been rejected by guard: %+v", err)
+	}
+
+	if err := m.Fire("start", "go"); err != nil {
+		t.Fatalf("Should have started machine: %+v", err)
+	}
+
+	if !m.Is("running") || m.Can("start") {
+		t.Fatalf("Should be running without start event: %q", m.Current())
+	}
+
+	if err := m.Fire("start", "go"); !errors.Is(err, fsm.ErrUnknownEvent) {
+		t.Fatalf("Should have rejected event not defined for running state: %+v", err)
+	}
+
+	if err := m.Fire("stop", nil); err != nil {
+		t.Fatalf("Should have stopped machine: %+v", err)
+	}
+
+	expected := []string{"exit-idle:start", "enter-running:start", "exit-running:stop", "enter-stopped:stop"}
+	if !reflect.DeepEqual(trail, expected) {
+		t.Fatalf("Should have called actions in order: %+v", trail)
+	}
+
+	if len(changes) != 2 || changes[1].From != "running" || changes[1].To != "stopped" {
+		t.Fatalf("Should have notified listener of changes: %+v", changes)
+	}
+
+	if err := m.Fire("reset", nil); err != nil || !m.Is("idle") {
+		t.Fatalf("Should have reset machine from any state: %+v", err)
+	}
+}
+
+func TestInvalidDefinition(t *testing.T) {
+	if _, err := fsm.New(fsm.Definition{}); err != fsm.ErrNoInitialState {
+		t.Fatalf("Should have required initial state: %+v", err)
+	}
+
+	if _, err := fsm.New(fsm.Definition{
+		Initial:     "a",
+		States:      []fsm.State{{Name: "a"}},
+		Transitions: []fsm.Transition{{Event: "go", To: "b"}},
+	}); !errors.Is(err, fsm.ErrUnknownState) {
+		t.Fatalf("Should have rejected unknown target state: %+v", err)
+	}
+
+	if _, err := fsm.New(fsm.Definition{
+		Initial: "a",
+		States:  []fsm.State{{Name: "a"}, {Name: "b"}},
+		Transitions: []fsm.Transition{
+			{Event: "go", To: "b"},
+			{Event: "go", From: []string{"a"}, To: "a"},
+		},
+	}); !errors.Is(err, fsm.ErrConflictingEvent) {
+		t.Fatalf("Should have rejected conflicting events: %+v", err)
+	}
+
+	if _, err := fsm.New(fsm.Definition{
+		Initial: "a",
+		States:  []fsm.State{{Name: "a"}, {Name: "a"}},
+	}); !errors.Is(err, fsm.ErrDuplicateState) {
+		t.Fatalf("Should have rejected duplicate states: %+v", err)
+	}
+
+	if _, err := fsm.New(fsm.Definition{
+		Initial: "c",
+		States:  []fsm.State{{Name: "a"}},
+	}); !errors.Is(err, fsm.ErrUnknownState) {
+		t.Fatalf("Should have rejected unknown initial state: %+v", err)
+	}
+}
+
+func TestMachineQueryFromCallbacks(t *testing.T) {
+	var m *fsm.Machine
+	var seen []string
+
+	m = fsm.MustNew(fsm.Definition{
+		Initial: "idle",
+		States: []fsm.State{
+			{Name: "idle", OnExit: func(fsm.Change) {
+				seen = append(seen, m.Current())
+			}},
+			{Name: "running", OnEnter: func(fsm.Change) {
+				seen = append(seen, m.Events()...)
+			}},
+		},
+		Transitions: []fsm.Transition{
+			{Event: "start", From: []string{"idle"}, To: "running", Guard: func(fsm.Change) bool {
+				return m.Can("start")
+			}},
+			{Event: "stop", From: []string{"running"}, To: "idle"},
+		},
+	}, func(fsm.Change) {
+		seen = append(seen, m.Current())
+	})
+
+	if err := m.Fire("start", nil); err != nil {
+		t.Fatalf("Should have fired event: %+v", err)
+	}
+
+	if expected := []string{"idle", "stop", "running"}; !reflect.DeepEqual(seen, expected) {
+		t.Fatalf("Should have queried machine from callbacks: %+v", seen)
+	}
+}
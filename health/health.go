@@ -0,0 +1,357 @@
+// Package health provides a registry of liveness and readiness checks whose
+// results are aggregated into an overall status, served over http and
+// periodically emitted as metrics entries.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+	"github.com/influx6/faux/ops"
+)
+
+// errors.
+var (
+	ErrCheckTimeout   = errors.New("health check timed out")
+	ErrNoCheckName    = errors.New("health check requires a name")
+	ErrNoCheckFunc    = errors.New("health check requires a check function")
+	ErrUnknownKind    = errors.New("health check kind must be Liveness or Readiness")
+	ErrDuplicateCheck = errors.New("health check already registered")
+	ErrShuttingDown   = errors.New("application is shutting down")
+	ErrNotReady       = errors.New("application is not ready")
+	ErrNotAlive       = errors.New("application is not alive")
+)
+
+// Kind defines the kind of a check.
+type Kind int
+
+// kinds of checks.
+const (
+	// Liveness checks report if the application should be restarted.
+	Liveness Kind = iota + 1
+
+	// Readiness checks report if the application can receive work.
+	Readiness
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Liveness:
+		return "liveness"
+	case Readiness:
+		return "readiness"
+	}
+	return "unknown"
+}
+
+// Status defines the outcome of a check.
+type Status string
+
+// statuses of checks.
+const (
+	Up   Status = "up"
+	Down Status = "down"
+)
+
+// CheckFunc defines a function type which checks a component, returning an
+// error if it is unhealthy.
+type CheckFunc func(context.Context) error
+
+// Check defines a named check of a component.
+type Check struct {
+	Name  string
+	Kind  Kind
+	Check CheckFunc
+
+	// Timeout bounds a single run of the check, defaulting to DefaultTimeout.
+	Timeout time.Duration
+
+	// TTL caches the result of the check for the giving duration, so
+	// frequent probes do not overload the component. Zero disables caching.
+	TTL time.Duration
+}
+
+// DefaultTimeout is used for checks without a Timeout.
+const DefaultTimeout = 5 * time.Second
+
+// Result defines the outcome of a single check.
+type Result struct {
+	Name     string        `json:"name"`
+	Kind     string        `json:"kind"`
+	Status   Status        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Checked  time.Time     `json:"checked"`
+}
+
+// Report defines the aggregated outcome of a set of checks, which is Up
+// only if all checks are Up.
+type Report struct {
+	Status Status   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+//==============================================================================
+
+// entry holds a registered check and it's cached result.
+type entry struct {
+	check  Check
+	ml     sync.Mutex
+	result Result
+	cached time.Time
+}
+
+// Registry holds the registered checks of an application.
+type Registry struct {
+	metrics metrics.Metrics
+	ml      sync.RWMutex
+	checks  map[string]*entry
+}
+
+// New returns a new instance of a Registry. The provided metrics may be
+// nil.
+func New(m metrics.Metrics) *Registry {
+	return &Registry{
+		metrics: m,
+		checks:  make(map[string]*entry),
+	}
+}
+
+// Register adds the giving check into the registry. The check must have a
+// name, a function and a Kind of Liveness or Readiness.
+func (r *Registry) Register(check Check) error {
+	if check.Name == "" {
+		return ErrNoCheckName
+	}
+
+	if check.Kind != Liveness && check.Kind != Readiness {
+		return ErrUnknownKind
+	}
+
+	if check.Check == nil {
+		return ErrNoCheckFunc
+	}
+
+	if check.Timeout <= 0 {
+		check.Timeout = DefaultTimeout
+	}
+
+	r.ml.Lock()
+	defer r.ml.Unlock()
+
+	if _, ok := r.checks[check.Name]; ok {
+		return ErrDuplicateCheck
+	}
+
+	r.checks[check.Name] = &entry{check: check}
+	return nil
+}
+
+// Unregister removes the check with the giving name.
+func (r *Registry) Unregister(name string) {
+	r.ml.Lock()
+	defer r.ml.Unlock()
+	delete(r.checks, name)
+}
+
+// Coordinator registers checks reporting the state of the provided
+// ops.Coordinator: a liveness check named "ops:alive", and a readiness check
+// named "ops:ready" which fails as soon as shutdown begins.
+func (r *Registry) Coordinator(c *ops.Coordinator) error {
+	if err := r.Register(Check{
+		Name: "ops:alive",
+		Kind: Liveness,
+		Check: func(context.Context) error {
+			if !c.Alive() {
+				return ErrNotAlive
+			}
+			return nil
+		},
+	}); err != nil {
+		return err
+	}
+
+	return r.Register(Check{
+		Name: "ops:ready",
+		Kind: Readiness,
+		Check: func(context.Context) error {
+			if c.ShuttingDown() {
+				return ErrShuttingDown
+			}
+			if !c.Ready() {
+				return ErrNotReady
+			}
+			return nil
+		},
+	})
+}
+
+// Liveness runs all liveness checks, returning their report.
+func (r *Registry) Liveness(ctx context.Context) Report {
+	return r.Run(ctx, Liveness)
+}
+
+// Readiness runs all readiness checks, returning their report.
+func (r *Registry) Readiness(ctx context.Context) Report {
+	return r.Run(ctx, Readiness)
+}
+
+// Run runs all checks of the giving kind concurrently, returning their
+// report. A zero kind runs all checks.
+func (r *Registry) Run(ctx context.Context, kind Kind) Report {
+	r.ml.RLock()
+	var entries []*entry
+	for _, en := range r.checks {
+		if kind == 0 || en.check.Kind == kind {
+			entries = append(entries, en)
+		}
+	}
+	r.ml.RUnlock()
+
+	report := Report{Status: Up, Checks: make([]Result, len(entries))}
+
+	var wg sync.WaitGroup
+	wg.Add(len(entries))
+	for index, en := range entries {
+		go func(index int, en *entry) {
+			defer wg.Done()
+			report.Checks[index] = en.run(ctx)
+		}(index, en)
+	}
+	wg.Wait()
+
+	sort.Slice(report.Checks, func(i, j int) bool {
+		return report.Checks[i].Name < report.Checks[j].Name
+	})
+
+	for _, result := range report.Checks {
+		if result.Status != Up {
+			report.Status = Down
+			break
+		}
+	}
+
+	return report
+}
+
+// Emit runs all checks, emitting an entry with the id "health:check" for
+// every check and one with the id "health:status" for the overall status.
+func (r *Registry) Emit(ctx context.Context) Report {
+	report := r.Run(ctx, 0)
+	if r.metrics == nil {
+		return report
+	}
+
+	for _, result := range report.Checks {
+		fields := metrics.Field{
+			"check":    result.Name,
+			"kind":     result.Kind,
+			"status":   string(result.Status),
+			"duration": result.Duration,
+		}
+
+		if result.Status != Up {
+			r.metrics.Emit(metrics.Errorf("Health check failed: %s", result.Error), metrics.WithID("health:check"), metrics.WithFields(fields))
+			continue
+		}
+
+		r.metrics.Emit(metrics.Info("Health check passed"), metrics.WithID("health:check"), metrics.WithFields(fields))
+	}
+
+	r.metrics.Emit(metrics.Info("Health status"), metrics.WithID("health:status"), metrics.With("status", string(report.Status)))
+	return report
+}
+
+// Watch calls Emit on every interval until the context is done.
+func (r *Registry) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.Emit(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Handler returns a http.Handler which responds with the report of the
+// checks of the giving kind as JSON, with a 503 status if it is Down. A
+// zero kind runs all checks.
+func (r *Registry) Handler(kind Kind) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := r.Run(req.Context(), kind)
+
+		w.Header().Set("Content-Type", "application/json")
+		if report.Status != Up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		json.NewEncoder(w).Encode(report)
+	})
+}
+
+//==============================================================================
+
+// run returns the cached result of the check if still valid, else runs the
+// check bounded by it's timeout. A run abandoned because the parent context
+// is done reports the context error and is not cached, so an aborted probe
+// does not mark the check as down for other callers.
+func (en *entry) run(ctx context.Context) Result {
+	en.ml.Lock()
+	defer en.ml.Unlock()
+
+	if en.check.TTL > 0 && !en.cached.IsZero() && time.Since(en.cached) < en.check.TTL {
+		return en.result
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, en.check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- en.check.Check(cctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errs:
+	case <-cctx.Done():
+		err = ErrCheckTimeout
+	}
+
+	abandoned := ctx.Err() != nil
+	if abandoned {
+		err = ctx.Err()
+	}
+
+	result := Result{
+		Name:     en.check.Name,
+		Kind:     en.check.Kind.String(),
+		Status:   Up,
+		Duration: time.Since(start),
+		Checked:  start,
+	}
+
+	if err != nil {
+		result.Status = Down
+		result.Error = err.Error()
+	}
+
+	if !abandoned {
+		en.result = result
+		en.cached = time.Now()
+	}
+
+	return result
+}
@@ -0,0 +1,146 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/health"
+	"github.com/influx6/faux/metrics"
+	"github.com/influx6/faux/ops"
+	"github.com/influx6/faux/tester"
+)
+
+func TestRegistry(t *testing.T) {
+	var rec tester.Recorder
+	registry := health.New(metrics.New(&rec))
+
+	var calls int32
+	registry.Register(health.Check{
+		Name: "db",
+		Kind: health.Readiness,
+		TTL:  time.Minute,
+		Check: func(context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		},
+	})
+
+	registry.Register(health.Check{
+		Name:    "slow",
+		Kind:    health.Liveness,
+		Timeout: 10 * time.Millisecond,
+		Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	if err := registry.Register(health.Check{Name: "db", Kind: health.Readiness, Check: func(context.Context) error { return nil }}); err != health.ErrDuplicateCheck {
+		t.Fatalf("Should have rejected duplicate check: %+v", err)
+	}
+
+	if report := registry.Readiness(context.Background()); report.Status != health.Up {
+		t.Fatalf("Should have reported ready: %+v", report)
+	}
+
+	registry.Readiness(context.Background())
+	if calls != 1 {
+		t.Fatalf("Should have cached check result: %d", calls)
+	}
+
+	report := registry.Liveness(context.Background())
+	if report.Status != health.Down || report.Checks[0].Error != health.ErrCheckTimeout.Error() {
+		t.Fatalf("Should have timed out slow check: %+v", report)
+	}
+
+	registry.Emit(context.Background())
+	if len(rec.Find("health:check")) != 2 || len(rec.Find("health:status")) != 1 {
+		t.Fatalf("Should have emitted check entries: %+v", rec.Entries())
+	}
+}
+
+func TestCoordinator(t *testing.T) {
+	co := ops.NewCoordinator(nil)
+	registry := health.New(nil)
+	registry.Coordinator(co)
+	registry.Register(health.Check{
+		Name:  "cache",
+		Kind:  health.Readiness,
+		Check: func(context.Context) error { return errors.New("unavailable") },
+	})
+	registry.Unregister("cache")
+
+	handler := registry.Handler(health.Readiness)
+	probe := func() (int, health.Report) {
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, httptest.NewRequest("GET", "/ready", nil))
+
+		var report health.Report
+		json.NewDecoder(res.Body).Decode(&report)
+		return res.Code, report
+	}
+
+	if code, _ := probe(); code != http.StatusServiceUnavailable {
+		t.Fatalf("Should not be ready before coordinator is: %d", code)
+	}
+
+	co.SetReady(true)
+	if code, report := probe(); code != http.StatusOK || report.Status != health.Up {
+		t.Fatalf("Should be ready: %d %+v", code, report)
+	}
+
+	co.Shutdown(context.Background())
+	if code, report := probe(); code != http.StatusServiceUnavailable || report.Checks[0].Error != health.ErrShuttingDown.Error() {
+		t.Fatalf("Should not be ready during shutdown: %d %+v", code, report)
+	}
+}
+
+func TestAbandonedCheck(t *testing.T) {
+	registry := health.New(nil)
+	registry.Register(health.Check{
+		Name: "db",
+		Kind: health.Readiness,
+		TTL:  time.Minute,
+		Check: func(ctx context.Context) error {
+			return ctx.Err()
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := registry.Readiness(ctx)
+	if report.Status != health.Down || report.Checks[0].Error != context.Canceled.Error() {
+		t.Fatalf("Should have reported cancelled probe: %+v", report)
+	}
+
+	if report := registry.Readiness(context.Background()); report.Status != health.Up {
+		t.Fatalf("Should not have cached abandoned result: %+v", report)
+	}
+}
+
+func TestRegisterValidation(t *testing.T) {
+	registry := health.New(nil)
+
+	if err := registry.Register(health.Check{Kind: health.Liveness, Check: func(context.Context) error { return nil }}); err != health.ErrNoCheckName {
+		t.Fatalf("Should have rejected check without name: %+v", err)
+	}
+
+	if err := registry.Register(health.Check{Name: "db", Check: func(context.Context) error { return nil }}); err != health.ErrUnknownKind {
+		t.Fatalf("Should have rejected check without kind: %+v", err)
+	}
+
+	if err := registry.Register(health.Check{Name: "db", Kind: health.Kind(3), Check: func(context.Context) error { return nil }}); err != health.ErrUnknownKind {
+		t.Fatalf("Should have rejected check with unknown kind: %+v", err)
+	}
+
+	if err := registry.Register(health.Check{Name: "db", Kind: health.Liveness}); err != health.ErrNoCheckFunc {
+		t.Fatalf("Should have rejected check without function: %+v", err)
+	}
+}
@@ -20,7 +20,7 @@ import (
 
 	"github.com/influx6/faux/bag"
 	"github.com/influx6/faux/metrics"
-	uuid "github.com/satori/go.uuid"
+	"github.com/influx6/faux/uuid"
 )
 
 const (
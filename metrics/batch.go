@@ -2,7 +2,10 @@ package metrics
 
 import (
 	"errors"
+	"sync"
 	"time"
+
+	"github.com/influx6/faux/batch"
 )
 
 // errors.
@@ -22,90 +25,63 @@ type CommitFunction func([]Entry) error
 
 // BatchConsumer returns a new instance of a batchConsumer.
 func BatchConsumer(maxSize int, maxwait time.Duration, fn CommitFunction) MetricConsumer {
-	var batch batchConsumer
-	batch.fn = fn
-	batch.maxlen = maxSize
-	batch.maxwait = maxwait
-	batch.actions = make(chan func(), 0)
+	var bm batchConsumer
+	bm.batcher = batch.New(batch.Config{
+		MaxItems: maxSize,
+		Interval: maxwait,
+		OnError:  bm.failed,
+	}, func(items []interface{}) error {
+		entries := make([]Entry, len(items))
+		for index, item := range items {
+			entries[index] = item.(Entry)
+		}
+		return fn(entries)
+	})
 
-	return &batch
+	return &bm
 }
 
 // batchConsumer defines a structure which collects Entry in batch
 // mode until a provide size threshold is met then it's provided against
 // a provided function for procesing.
 type batchConsumer struct {
-	maxlen    int
+	ml        sync.Mutex
 	commitErr error
-	batch     []Entry
-	actions   chan func()
-	maxwait   time.Duration
-	fn        CommitFunction
+	batcher   *batch.Batcher
 }
 
 // Handle takes provided entries and emits giving entries into batch,
 // returning any error encountered with the addition of the entry
 // or one received during the last commit of the entries.
 func (bm *batchConsumer) Handle(en Entry) error {
-	if bm.commitErr != nil {
-		return bm.commitErr
-	}
-
-	errChan := make(chan error, 0)
-	action := func() {
-		bm.batch = append(bm.batch, en)
+	bm.ml.Lock()
+	err := bm.commitErr
+	bm.ml.Unlock()
 
-		if len(bm.batch) >= bm.maxlen {
-			batch := bm.batch
-			bm.batch = nil
+	if err != nil {
+		return err
+	}
 
-			if err := bm.fn(batch); err != nil {
-				errChan <- err
-				return
-			}
+	if err := bm.batcher.Add(en); err != nil {
+		if err == batch.ErrClosed {
+			return ErrBatchEmitterClosed
 		}
-
-		close(errChan)
+		return err
 	}
 
-	select {
-	case bm.actions <- action:
-		return <-errChan
-	case <-time.After(5 * time.Millisecond):
-		return nil
-	}
+	return nil
 }
 
-// Emit takes provided entries and emits giving entries into batch loop.
-func (bm *batchConsumer) commit() {
-	bm.actions <- func() {
-		if len(bm.batch) == 0 {
-			return
-		}
-
-		batch := bm.batch
-		bm.batch = nil
-		bm.commitErr = bm.fn(batch)
-	}
+// failed records the error of a failed commit to be returned by Handle.
+func (bm *batchConsumer) failed(_ []interface{}, err error) {
+	bm.ml.Lock()
+	bm.commitErr = err
+	bm.ml.Unlock()
 }
 
-// Run handles running the necessary logic to add entries into the batch
-// and call the necessary commit call to evaluate the provided function with
-// the collected Entries.
+// Run blocks until the closeChan is closed, after which all pending
+// Entries are committed and further calls to Handle are rejected.
 func (bm *batchConsumer) Run(closeChan <-chan struct{}) {
-	ticker := time.NewTimer(bm.maxwait)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			ticker.Reset(bm.maxwait)
-			go bm.commit()
-		case action := <-bm.actions:
-			ticker.Reset(bm.maxwait)
-			action()
-		case <-closeChan:
-			return
-		}
-	}
+	<-closeChan
+	bm.batcher.Close()
 }
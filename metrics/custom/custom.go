@@ -11,6 +11,7 @@ import (
 
 	"github.com/fatih/color"
 
+	"github.com/influx6/faux/dump"
 	"github.com/influx6/faux/metrics"
 )
 
@@ -305,7 +306,7 @@ func printItem(item interface{}) string {
 
 	data, err := json.Marshal(item)
 	if err != nil {
-		return fmt.Sprintf("%#v", item)
+		return dump.Compact.Sprint(item)
 	}
 
 	return string(data)
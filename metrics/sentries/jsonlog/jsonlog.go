@@ -0,0 +1,239 @@
+// Package jsonlog provides a metrics sentry which writes every Entry as a
+// single line of JSON, for ingestion by log collectors like Fluentd or Vector.
+//
+// Every line carries the time, level and message of the entry, followed by
+// its id, caller, tags and fields where set:
+//
+//	{"time":"2018-03-01T10:00:00Z","level":"info","message":"Store operation","id":"store:get","store":"users","user.id":20}
+//
+// Nested maps within fields are flattened into dotted keys, and fields which
+// collide with the reserved keys are prefixed with "fields.". Where keys still
+// collide, the field with the shortest path keeps the key and the others are
+// suffixed with "_2", "_3" and so on.
+package jsonlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+)
+
+// reserved defines the keys written for every entry.
+var reserved = map[string]bool{
+	"time":     true,
+	"level":    true,
+	"message":  true,
+	"id":       true,
+	"function": true,
+	"file":     true,
+	"line":     true,
+	"tags":     true,
+}
+
+// JSONLog implements the metrics.Processors interface, writing entries as
+// JSON lines into a io.Writer.
+type JSONLog struct {
+	ml         sync.Mutex
+	w          io.Writer
+	timeFormat string
+	filter     func(metrics.Entry) bool
+}
+
+// New returns a new instance of a JSONLog which writes all entries into w
+// with times formatted as time.RFC3339Nano.
+func New(w io.Writer) *JSONLog {
+	return NewWith(w, time.RFC3339Nano, nil)
+}
+
+// NewWith returns a new instance of a JSONLog which formats times with the
+// giving layout and only writes entries for which filterFn returns true. A
+// nil filterFn writes all entries.
+func NewWith(w io.Writer, timeFormat string, filterFn func(metrics.Entry) bool) *JSONLog {
+	return &JSONLog{
+		w:          w,
+		timeFormat: timeFormat,
+		filter:     filterFn,
+	}
+}
+
+// Handle implements the metrics.Processors interface.
+func (j *JSONLog) Handle(en metrics.Entry) error {
+	if j.filter != nil && !j.filter(en) {
+		return nil
+	}
+
+	line := j.Format(en)
+
+	j.ml.Lock()
+	defer j.ml.Unlock()
+
+	_, err := j.w.Write(line)
+	return err
+}
+
+// Format returns the JSON line for the entry, ending with a newline.
+func (j *JSONLog) Format(en metrics.Entry) []byte {
+	stamp := en.Time
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	var bu bytes.Buffer
+	bu.WriteString("{")
+
+	writeKey(&bu, "time", true)
+	writeValue(&bu, stamp.Format(j.timeFormat))
+
+	writeKey(&bu, "level", false)
+	writeValue(&bu, strings.ToLower(en.Level.String()))
+
+	writeKey(&bu, "message", false)
+	writeValue(&bu, en.Message)
+
+	if en.ID != "" {
+		writeKey(&bu, "id", false)
+		writeValue(&bu, en.ID)
+	}
+
+	if en.Function != "" {
+		writeKey(&bu, "function", false)
+		writeValue(&bu, en.Function)
+		writeKey(&bu, "file", false)
+		writeValue(&bu, en.File)
+		writeKey(&bu, "line", false)
+		writeValue(&bu, en.Line)
+	}
+
+	if len(en.Tags) != 0 {
+		writeKey(&bu, "tags", false)
+		writeValue(&bu, en.Tags)
+	}
+
+	for _, field := range resolve(en.Field) {
+		writeKey(&bu, field.name, false)
+		writeValue(&bu, field.value)
+	}
+
+	bu.WriteString("}\n")
+	return bu.Bytes()
+}
+
+//==============================================================================
+
+// field defines a flattened field with the path of keys leading to it.
+type field struct {
+	name  string
+	path  []string
+	value interface{}
+}
+
+// resolve flattens the fields and names them, prefixing reserved keys with
+// "fields.". Where names collide, such as a literal "user.id" key and a
+// nested "user" map holding "id", the field with the shorter path keeps the
+// name and the others are suffixed with "_2", "_3" and so on in path order,
+// so every line carries unique keys regardless of map iteration order.
+func resolve(fields metrics.Field) []field {
+	var flat []field
+	flatten(nil, fields, &flat)
+
+	for index := range flat {
+		name := strings.Join(flat[index].path, ".")
+		if reserved[name] {
+			name = "fields." + name
+		}
+		flat[index].name = name
+	}
+
+	sort.Slice(flat, func(i, j int) bool {
+		if flat[i].name != flat[j].name {
+			return flat[i].name < flat[j].name
+		}
+		if len(flat[i].path) != len(flat[j].path) {
+			return len(flat[i].path) < len(flat[j].path)
+		}
+		return strings.Join(flat[i].path, "\x00") < strings.Join(flat[j].path, "\x00")
+	})
+
+	taken := make(map[string]bool, len(flat))
+	for _, item := range flat {
+		taken[item.name] = true
+	}
+
+	seen := make(map[string]int, len(flat))
+	for index := range flat {
+		name := flat[index].name
+		seen[name]++
+		if seen[name] == 1 {
+			continue
+		}
+
+		suffix := seen[name]
+		candidate := fmt.Sprintf("%s_%d", name, suffix)
+		for taken[candidate] {
+			suffix++
+			candidate = fmt.Sprintf("%s_%d", name, suffix)
+		}
+
+		seen[name] = suffix
+		taken[candidate] = true
+		flat[index].name = candidate
+	}
+
+	sort.SliceStable(flat, func(i, j int) bool {
+		return flat[i].name < flat[j].name
+	})
+
+	return flat
+}
+
+// flatten appends the values of fields into flat, recording the path of
+// keys through nested maps.
+func flatten(prefix []string, fields map[string]interface{}, flat *[]field) {
+	for key, value := range fields {
+		path := append(append([]string(nil), prefix...), key)
+
+		switch item := value.(type) {
+		case metrics.Field:
+			flatten(path, item, flat)
+		case map[string]interface{}:
+			flatten(path, item, flat)
+		default:
+			*flat = append(*flat, field{path: path, value: item})
+		}
+	}
+}
+
+func writeKey(bu *bytes.Buffer, key string, first bool) {
+	if !first {
+		bu.WriteString(",")
+	}
+
+	writeValue(bu, key)
+	bu.WriteString(":")
+}
+
+// writeValue writes the JSON encoding of value, using the error message for
+// errors, the String method of durations, and the %+v formatting for values
+// which can not be encoded.
+func writeValue(bu *bytes.Buffer, value interface{}) {
+	switch item := value.(type) {
+	case error:
+		value = item.Error()
+	case time.Duration:
+		value = item.String()
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprintf("%+v", value))
+	}
+
+	bu.Write(data)
+}
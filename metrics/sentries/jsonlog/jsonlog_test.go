@@ -0,0 +1,149 @@
+package jsonlog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+	"github.com/influx6/faux/metrics/sentries/jsonlog"
+)
+
+func TestJSONLog(t *testing.T) {
+	var out bytes.Buffer
+	m := metrics.New(jsonlog.New(&out))
+
+	m.Emit(metrics.Info("Store operation"), metrics.WithID("store:get"), metrics.WithFields(metrics.Field{
+		"store":    "users",
+		"duration": 2 * time.Second,
+		"user":     metrics.Field{"id": 20},
+		"message":  "shadowed",
+		"callback": func() {},
+	}))
+
+	m.Emit(metrics.Error(errors.New("bad request")), metrics.Tags("http"))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Should have written a line per entry: %q", out.String())
+	}
+
+	var first map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("Should have written valid json: %+v", err)
+	}
+
+	expected := map[string]interface{}{
+		"level":          "info",
+		"message":        "Store operation",
+		"id":             "store:get",
+		"store":          "users",
+		"duration":       "2s",
+		"user.id":        float64(20),
+		"fields.message": "shadowed",
+	}
+
+	for key, value := range expected {
+		if first[key] != value {
+			t.Fatalf("Should have written %q as %+v: %+v", key, value, first[key])
+		}
+	}
+
+	if _, err := time.Parse(time.RFC3339Nano, first["time"].(string)); err != nil {
+		t.Fatalf("Should have written RFC3339 time: %+v", err)
+	}
+
+	if first["callback"] == nil {
+		t.Fatalf("Should have written unencodable values as text")
+	}
+
+	var second map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("Should have written valid json: %+v", err)
+	}
+
+	if second["level"] != "error" || second["message"] != "bad request" || second["tags"] == nil {
+		t.Fatalf("Should have written error entry: %+v", second)
+	}
+}
+
+func TestJSONLogFilter(t *testing.T) {
+	var out bytes.Buffer
+	m := metrics.New(jsonlog.NewWith(&out, time.RFC3339, func(en metrics.Entry) bool {
+		return en.Level == metrics.ErrorLvl
+	}))
+
+	m.Emit(metrics.Info("ignored"))
+	m.Emit(metrics.Errorf("kept"))
+
+	if strings.Count(out.String(), "\n") != 1 || !strings.Contains(out.String(), `"message":"kept"`) {
+		t.Fatalf("Should have written filtered entries only: %q", out.String())
+	}
+}
+
+func TestJSONLogFlattenCollision(t *testing.T) {
+	log := jsonlog.New(nil)
+	en := metrics.Entry{
+		Time:    time.Now(),
+		Message: "collision",
+		Field: metrics.Field{
+			"user.id": 1,
+			"user":    metrics.Field{"id": 2, "name": "bob"},
+		},
+	}
+
+	line := string(log.Format(en))
+	for i := 0; i < 50; i++ {
+		if again := string(log.Format(en)); again != line {
+			t.Fatalf("Should have written colliding keys deterministically: %q %q", line, again)
+		}
+	}
+
+	if strings.Count(line, `"user.id":`) != 1 {
+		t.Fatalf("Should have written key once: %q", line)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+		t.Fatalf("Should have written valid json: %+v", err)
+	}
+
+	if decoded["user.id"] != float64(1) || decoded["user.id_2"] != float64(2) || decoded["user.name"] != "bob" {
+		t.Fatalf("Should have kept literal key and suffixed flattened key: %+v", decoded)
+	}
+}
+
+func TestJSONLogReservedCollision(t *testing.T) {
+	log := jsonlog.New(nil)
+	en := metrics.Entry{
+		Time:    time.Now(),
+		Message: "collision",
+		Field: metrics.Field{
+			"message": "shadowed",
+			"fields":  metrics.Field{"message": "nested"},
+		},
+	}
+
+	line := string(log.Format(en))
+	for i := 0; i < 50; i++ {
+		if again := string(log.Format(en)); again != line {
+			t.Fatalf("Should have written colliding keys deterministically: %q %q", line, again)
+		}
+	}
+
+	if strings.Count(line, `"fields.message":`) != 1 {
+		t.Fatalf("Should have written key once: %q", line)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+		t.Fatalf("Should have written valid json: %+v", err)
+	}
+
+	if decoded["message"] != "collision" || decoded["fields.message"] != "shadowed" || decoded["fields.message_2"] != "nested" {
+		t.Fatalf("Should have kept prefixed reserved key and suffixed nested key: %+v", decoded)
+	}
+}
@@ -0,0 +1,301 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+)
+
+// errors.
+var (
+	ErrShutdownStarted = errors.New("shutdown already started")
+	ErrStageTimeout    = errors.New("shutdown stage timed out")
+)
+
+// CloseFunc defines a function type which releases a resource during shutdown,
+// returning once done or when the provided context expires.
+type CloseFunc func(context.Context) error
+
+// IOCloser returns a CloseFunc which calls the Close method of the provided
+// io.Closer.
+func IOCloser(c io.Closer) CloseFunc {
+	return func(context.Context) error {
+		return c.Close()
+	}
+}
+
+// SimpleCloser returns a CloseFunc which calls the provided function.
+func SimpleCloser(fn func()) CloseFunc {
+	return func(context.Context) error {
+		fn()
+		return nil
+	}
+}
+
+// ShutdownErrors defines the errors returned by closers during shutdown,
+// keyed by stage and closer name as "stage/closer".
+type ShutdownErrors map[string]error
+
+// Error implements the error interface.
+func (se ShutdownErrors) Error() string {
+	names := make([]string, 0, len(se))
+	for name := range se {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var bu bytes.Buffer
+	for index, name := range names {
+		if index > 0 {
+			bu.WriteString("; ")
+		}
+
+		bu.WriteString(name)
+		bu.WriteString(": ")
+		bu.WriteString(se[name].Error())
+	}
+
+	return bu.String()
+}
+
+//==============================================================================
+
+// Stage defines a group of closers which are run concurrently during
+// shutdown, bounded by the stage timeout.
+type Stage struct {
+	name    string
+	timeout time.Duration
+	ml      sync.Mutex
+	names   []string
+	closers []CloseFunc
+}
+
+// Add registers the giving closer with the stage.
+func (s *Stage) Add(name string, fn CloseFunc) *Stage {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	s.names = append(s.names, name)
+	s.closers = append(s.closers, fn)
+	return s
+}
+
+// close runs all closers of the stage concurrently, waiting until all are
+// done or the stage timeout expires. Errors of failed and expired closers are
+// added into errs.
+func (s *Stage) close(ctx context.Context, errs ShutdownErrors) {
+	s.ml.Lock()
+	names, closers := s.names, s.closers
+	s.ml.Unlock()
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var ml sync.Mutex
+	var expired bool
+	var pending sync.WaitGroup
+
+	done := make([]bool, len(closers))
+
+	for index, closer := range closers {
+		pending.Add(1)
+		go func(index int, closer CloseFunc) {
+			defer pending.Done()
+
+			err := closer(ctx)
+
+			ml.Lock()
+			defer ml.Unlock()
+
+			done[index] = true
+			if err != nil && !expired {
+				errs[s.name+"/"+names[index]] = err
+			}
+		}(index, closer)
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		pending.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	}
+
+	ml.Lock()
+	defer ml.Unlock()
+
+	expired = true
+	for index, name := range names {
+		if !done[index] {
+			errs[s.name+"/"+name] = ErrStageTimeout
+		}
+	}
+}
+
+//==============================================================================
+
+// Coordinator manages the graceful shutdown of an application, running
+// registered stages in the order they were added. It also holds the
+// readiness and liveness state of the application, where readiness is
+// dropped as soon as shutdown begins.
+type Coordinator struct {
+	metrics  metrics.Metrics
+	ready    int32
+	alive    int32
+	started  int32
+	ml       sync.Mutex
+	stages   []*Stage
+	done     chan struct{}
+	errs     error
+	doneOnce sync.Once
+}
+
+// NewCoordinator returns a new instance of a Coordinator which is alive but
+// not ready. The provided metrics may be nil.
+func NewCoordinator(m metrics.Metrics) *Coordinator {
+	return &Coordinator{
+		metrics: m,
+		alive:   1,
+		done:    make(chan struct{}),
+	}
+}
+
+// Stage returns a new Stage with the giving timeout, which runs after all
+// previously added stages during shutdown.
+func (c *Coordinator) Stage(name string, timeout time.Duration) *Stage {
+	c.ml.Lock()
+	defer c.ml.Unlock()
+
+	stage := &Stage{name: name, timeout: timeout}
+	c.stages = append(c.stages, stage)
+	return stage
+}
+
+// SetReady sets the readiness state of the application.
+func (c *Coordinator) SetReady(ready bool) {
+	if ready && atomic.LoadInt32(&c.started) == 1 {
+		return
+	}
+
+	atomic.StoreInt32(&c.ready, boolToInt32(ready))
+}
+
+// SetAlive sets the liveness state of the application.
+func (c *Coordinator) SetAlive(alive bool) {
+	atomic.StoreInt32(&c.alive, boolToInt32(alive))
+}
+
+// Ready returns true if the application is ready to receive work.
+func (c *Coordinator) Ready() bool {
+	return atomic.LoadInt32(&c.ready) == 1
+}
+
+// Alive returns true if the application is alive.
+func (c *Coordinator) Alive() bool {
+	return atomic.LoadInt32(&c.alive) == 1
+}
+
+// ShuttingDown returns true if shutdown has begun.
+func (c *Coordinator) ShuttingDown() bool {
+	return atomic.LoadInt32(&c.started) == 1
+}
+
+// Done returns a channel which is closed once shutdown has completed.
+func (c *Coordinator) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns the error of the completed shutdown, if any.
+func (c *Coordinator) Err() error {
+	<-c.done
+	return c.errs
+}
+
+// Shutdown drops readiness and runs all stages in order, returning a
+// ShutdownErrors if any closer failed or timed out. The provided context
+// bounds the entire shutdown.
+func (c *Coordinator) Shutdown(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return ErrShutdownStarted
+	}
+
+	atomic.StoreInt32(&c.ready, 0)
+
+	c.ml.Lock()
+	stages := c.stages
+	c.ml.Unlock()
+
+	errs := make(ShutdownErrors)
+
+	for _, stage := range stages {
+		start := time.Now()
+		stage.close(ctx, errs)
+
+		if c.metrics != nil {
+			c.metrics.Emit(metrics.Info("Shutdown stage completed"), metrics.WithID("ops:shutdown"), metrics.WithFields(metrics.Field{
+				"stage":    stage.name,
+				"duration": time.Since(start),
+			}))
+		}
+	}
+
+	atomic.StoreInt32(&c.alive, 0)
+
+	if len(errs) != 0 {
+		c.errs = errs
+
+		if c.metrics != nil {
+			c.metrics.Emit(metrics.Error(c.errs), metrics.WithID("ops:shutdown:error"))
+		}
+	}
+
+	c.doneOnce.Do(func() { close(c.done) })
+	return c.errs
+}
+
+// Listen blocks until one of the giving signals is received or the context
+// is done, then runs Shutdown bounded by the provided timeout. If no signals
+// are provided, SIGINT and SIGTERM are used.
+func (c *Coordinator) Listen(ctx context.Context, timeout time.Duration, sigs ...os.Signal) error {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	defer signal.Stop(ch)
+
+	select {
+	case <-ch:
+	case <-ctx.Done():
+	}
+
+	sctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return c.Shutdown(sctx)
+}
+
+func boolToInt32(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package ops_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/ops"
+)
+
+func TestCoordinatorShutdown(t *testing.T) {
+	var ml sync.Mutex
+	var order []string
+
+	record := func(name string) ops.CloseFunc {
+		return func(context.Context) error {
+			ml.Lock()
+			defer ml.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	co := ops.NewCoordinator(nil)
+	co.SetReady(true)
+
+	co.Stage("servers", time.Second).Add("http", record("http"))
+	co.Stage("workers", time.Second).Add("queue", record("queue"))
+	co.Stage("stores", time.Second).Add("db", record("db"))
+
+	if !co.Ready() || !co.Alive() {
+		t.Fatalf("Should be ready and alive before shutdown")
+	}
+
+	if err := co.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Should have shutdown without error: %+q", err)
+	}
+
+	if len(order) != 3 || order[0] != "http" || order[1] != "queue" || order[2] != "db" {
+		t.Fatalf("Should have closed stages in order: %+v", order)
+	}
+
+	if co.Ready() || co.Alive() {
+		t.Fatalf("Should not be ready or alive after shutdown")
+	}
+
+	if err := co.Shutdown(context.Background()); err != ops.ErrShutdownStarted {
+		t.Fatalf("Should have failed repeated shutdown: %+q", err)
+	}
+	t.Logf("Should have closed stages in order")
+}
+
+func TestCoordinatorStageTimeout(t *testing.T) {
+	failed := errors.New("failed")
+
+	co := ops.NewCoordinator(nil)
+	co.Stage("slow", 10*time.Millisecond).
+		Add("hung", func(ctx context.Context) error {
+			<-time.After(200 * time.Millisecond)
+			return nil
+		}).
+		Add("broken", func(context.Context) error {
+			return failed
+		})
+
+	err := co.Shutdown(context.Background())
+
+	errs, ok := err.(ops.ShutdownErrors)
+	if !ok {
+		t.Fatalf("Should have received ShutdownErrors: %+q", err)
+	}
+
+	if errs["slow/hung"] != ops.ErrStageTimeout {
+		t.Fatalf("Should have timed out hung closer: %+q", errs)
+	}
+
+	if errs["slow/broken"] != failed {
+		t.Fatalf("Should have recorded failed closer: %+q", errs)
+	}
+
+	select {
+	case <-co.Done():
+	default:
+		t.Fatalf("Should have closed done channel")
+	}
+	t.Logf("Should have recorded stage errors: %s", err)
+}
@@ -0,0 +1,187 @@
+package pattern
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// errors.
+var (
+	ErrBadGlob = errors.New("malformed glob pattern")
+)
+
+// GlobMatcher defines a compiled glob pattern for matching slash separated
+// names, i.e file paths or topic names.
+//
+//	`*`      matches any sequence of characters except '/'
+//	`**`     matches any sequence of characters including '/'
+//	`?`      matches a single character except '/'
+//	`[abc]`  matches a single character of the class, `[!abc]` negates it
+//	`{a,b}`  matches any of the comma separated alternatives
+type GlobMatcher struct {
+	*regexp.Regexp
+	pattern  string
+	literals int
+	wilds    int
+}
+
+// Glob returns a new GlobMatcher for the giving pattern.
+func Glob(pattern string) (*GlobMatcher, error) {
+	var bu bytes.Buffer
+	var literals, wilds, alternates int
+
+	bu.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			wilds++
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+
+				// a "**/" segment also matches no directories at all.
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					bu.WriteString("(?:.*/)?")
+					continue
+				}
+
+				bu.WriteString(".*")
+				continue
+			}
+			bu.WriteString("[^/]*")
+		case '?':
+			wilds++
+			bu.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, ErrBadGlob
+			}
+
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			wilds++
+			bu.WriteString("[" + class + "]")
+			i += end + 1
+		case '{':
+			alternates++
+			wilds++
+			bu.WriteString("(?:")
+		case '}':
+			if alternates == 0 {
+				return nil, ErrBadGlob
+			}
+			alternates--
+			bu.WriteString(")")
+		case ',':
+			if alternates > 0 {
+				bu.WriteString("|")
+				continue
+			}
+			literals++
+			bu.WriteString(",")
+		case '\\':
+			if i+1 == len(pattern) {
+				return nil, ErrBadGlob
+			}
+			i++
+			literals++
+			bu.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			literals++
+			bu.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if alternates != 0 {
+		return nil, ErrBadGlob
+	}
+
+	bu.WriteString("$")
+
+	rx, err := regexp.Compile(bu.String())
+	if err != nil {
+		return nil, ErrBadGlob
+	}
+
+	return &GlobMatcher{
+		Regexp:   rx,
+		pattern:  pattern,
+		literals: literals,
+		wilds:    wilds,
+	}, nil
+}
+
+// MustGlob calls Glob, panicking if the pattern is malformed.
+func MustGlob(pattern string) *GlobMatcher {
+	g, err := Glob(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// Pattern returns the glob pattern of the matcher.
+func (g *GlobMatcher) Pattern() string {
+	return g.pattern
+}
+
+// Match returns true/false if the giving name matches the glob.
+func (g *GlobMatcher) Match(name string) bool {
+	return g.MatchString(name)
+}
+
+// Priority returns the priority of the glob, where lower values are more
+// specific. Patterns without wildcards have a priority of 0.
+func (g *GlobMatcher) Priority() int {
+	return g.wilds
+}
+
+//==============================================================================
+
+// GlobSet defines a list of globs for matching names against, ordered from
+// most to least specific.
+type GlobSet []*GlobMatcher
+
+// NewGlobSet returns a new GlobSet of the giving patterns.
+func NewGlobSet(patterns ...string) (GlobSet, error) {
+	set := make(GlobSet, 0, len(patterns))
+	for _, pattern := range patterns {
+		g, err := Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, g)
+	}
+
+	sort.SliceStable(set, func(i, j int) bool {
+		if set[i].wilds != set[j].wilds {
+			return set[i].wilds < set[j].wilds
+		}
+		return set[i].literals > set[j].literals
+	})
+
+	return set, nil
+}
+
+// Match returns true/false if the name matches any glob of the set.
+func (gs GlobSet) Match(name string) bool {
+	return gs.First(name) != nil
+}
+
+// First returns the most specific glob which matches the name, or nil.
+func (gs GlobSet) First(name string) *GlobMatcher {
+	for _, g := range gs {
+		if g.MatchString(name) {
+			return g
+		}
+	}
+	return nil
+}
@@ -5,12 +5,16 @@
 //
 // 		pattern: /name/{id:[/\d+/]}/log/{date:[/\w+\W+/]}
 // 		pattern: /name/:id
+// 		pattern: /users/:id/files/*path
 //
+// It also provides glob matchers for matching names and paths against shell
+// style patterns, i.e `src/**/*.go`.
 //
 package pattern
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -38,20 +42,31 @@ type URIMatcher interface {
 // matchProvider provides a class array-path matcher
 type matchProvider struct {
 	pattern  string
+	catchAll string
 	matchers Matchers
 	endless  bool
 	priority int
 }
 
-// New returns a new instance of a URIMatcher.
+// New returns a new instance of a URIMatcher. A pattern ending with a named
+// wildcard such as /files/*path is endless, with the remaining path stored
+// in the parameter of that name.
 func New(pattern string) URIMatcher {
 	pattern = addSlash(pattern)
+	original := pattern
+
+	var catchAll string
+	if parts := namedEndless.FindStringSubmatch(pattern); parts != nil {
+		catchAll = parts[1]
+		pattern = strings.TrimSuffix(pattern, parts[0]) + "/*"
+	}
 
 	pm := SegmentList(pattern)
 
 	m := matchProvider{
 		priority: CheckPriority(pattern),
-		pattern:  pattern,
+		pattern:  original,
+		catchAll: catchAll,
 		matchers: pm,
 		endless:  IsEndless(pattern),
 	}
@@ -59,6 +74,26 @@ func New(pattern string) URIMatcher {
 	return &m
 }
 
+// SortByPriority sorts the giving matchers into the order they should be
+// tried in, where endless patterns come after all others, then by their
+// priority and then by their number of segments, longest first.
+func SortByPriority(matchers []URIMatcher) {
+	sort.SliceStable(matchers, func(i, j int) bool {
+		left, right := matchers[i], matchers[j]
+
+		leftEndless, rightEndless := IsEndless(left.Pattern()), IsEndless(right.Pattern())
+		if leftEndless != rightEndless {
+			return rightEndless
+		}
+
+		if left.Priority() != right.Priority() {
+			return left.Priority() < right.Priority()
+		}
+
+		return len(splitPattern(left.Pattern())) > len(splitPattern(right.Pattern()))
+	})
+}
+
 // Priority returns the priority status of this giving pattern.
 func (m *matchProvider) Priority() int {
 	return m.priority
@@ -72,6 +107,15 @@ func (m *matchProvider) Pattern() string {
 // Validate returns true/false if the giving string matches the pattern, returning
 // a map of parameters match against segments of the pattern.
 func (m *matchProvider) Validate(path string) (Params, string, bool) {
+	param, rem, state := m.validate(path)
+	if state && m.catchAll != "" {
+		param[m.catchAll] = strings.TrimPrefix(rem, "/")
+	}
+
+	return param, rem, state
+}
+
+func (m *matchProvider) validate(path string) (Params, string, bool) {
 	path = addSlash(path)
 	stripped := stripAndClean(path)
 	hashedSrc := stripAndCleanButHash(path)
@@ -149,3 +149,101 @@ func TestRegExpPattern(t *testing.T) {
 		t.Fatalf("incorrect pattern: %+s %t", param, state)
 	}
 }
+
+func TestNamedEndlessPattern(t *testing.T) {
+	r := pattern.New(`/users/:id/files/*path`)
+
+	param, rem, state := r.Validate(`/users/12/files/docs/report.pdf`)
+	if !state {
+		t.Fatalf("incorrect pattern: %+s %t", param, state)
+	}
+
+	if param["id"] != "12" || param["path"] != "docs/report.pdf" {
+		t.Fatalf("incorrect params: %+s", param)
+	}
+
+	if rem != "/docs/report.pdf" {
+		t.Fatalf("incorrect remainer: Expected[%s] Got[%s]", "/docs/report.pdf", rem)
+	}
+
+	if _, _, state := r.Validate(`/users/12/images/logo.png`); state {
+		t.Fatalf("incorrect pattern: should not match other paths")
+	}
+}
+
+func TestSortByPriority(t *testing.T) {
+	matchers := []pattern.URIMatcher{
+		pattern.New(`/users/*path`),
+		pattern.New(`/users/:id`),
+		pattern.New(`/users/{id:[\d+]}`),
+		pattern.New(`/users/new`),
+		pattern.New(`/users/:id/files`),
+	}
+
+	pattern.SortByPriority(matchers)
+
+	expected := []string{`/users/new`, `/users/{id:[\d+]}`, `/users/:id/files`, `/users/:id`, `/users/*path`}
+	for index, m := range matchers {
+		if m.Pattern() != expected[index] {
+			t.Fatalf("incorrect order at %d: Expected[%s] Got[%s]", index, expected[index], m.Pattern())
+		}
+	}
+}
+
+func TestGlob(t *testing.T) {
+	cases := []struct {
+		glob  string
+		name  string
+		match bool
+	}{
+		{`*.go`, `main.go`, true},
+		{`*.go`, `cmd/main.go`, false},
+		{`**/*.go`, `main.go`, true},
+		{`**/*.go`, `cmd/app/main.go`, true},
+		{`src/**`, `src/a/b`, true},
+		{`file?.txt`, `file1.txt`, true},
+		{`file?.txt`, `file10.txt`, false},
+		{`[abc].md`, `b.md`, true},
+		{`[!abc].md`, `b.md`, false},
+		{`*.{js,css}`, `app.css`, true},
+		{`*.{js,css}`, `app.html`, false},
+		{`metrics.*`, `metrics.cpu`, true},
+		{`a\*b`, `a*b`, true},
+	}
+
+	for _, c := range cases {
+		g, err := pattern.Glob(c.glob)
+		if err != nil {
+			t.Fatalf("failed to compile glob %q: %+s", c.glob, err)
+		}
+
+		if g.Match(c.name) != c.match {
+			t.Fatalf("incorrect match of %q against %q: Expected[%t]", c.name, c.glob, c.match)
+		}
+	}
+
+	for _, bad := range []string{`[abc`, `{a,b`, `a}`, `a\`} {
+		if _, err := pattern.Glob(bad); err != pattern.ErrBadGlob {
+			t.Fatalf("should have rejected malformed glob %q", bad)
+		}
+	}
+}
+
+func TestGlobSet(t *testing.T) {
+	set, err := pattern.NewGlobSet(`**`, `*.go`, `main.go`)
+	if err != nil {
+		t.Fatalf("failed to compile globs: %+s", err)
+	}
+
+	if g := set.First(`main.go`); g == nil || g.Pattern() != `main.go` {
+		t.Fatalf("should have matched most specific glob first")
+	}
+
+	if g := set.First(`util.go`); g == nil || g.Pattern() != `*.go` {
+		t.Fatalf("should have matched *.go glob")
+	}
+
+	if !set.Match(`docs/readme.md`) {
+		t.Fatalf("should have matched catch-all glob")
+	}
+}
@@ -9,9 +9,10 @@ import (
 
 //==============================================================================
 
-var endless = regexp.MustCompile(`/\*$`)
+var endless = regexp.MustCompile(`/\*\w*$`)
+var namedEndless = regexp.MustCompile(`/\*(\w+)$`)
 
-//IsEndless returns true/false if the pattern as a /*
+//IsEndless returns true/false if the pattern as a /* or a named /*path
 func IsEndless(s string) bool {
 	return endless.MatchString(s)
 }
@@ -0,0 +1,319 @@
+// Package promise provides futures which settle once with a value or an
+// error, promises to settle them by hand, chaining through Then, Catch and
+// Finally and the All, Race and Any combinators.
+package promise
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/influx6/faux/panics"
+)
+
+// errors.
+var (
+	ErrTimeout   = errors.New("future timed out")
+	ErrNoFutures = errors.New("no futures provided")
+)
+
+// Future defines a value which becomes available later, settling exactly once
+// as resolved with a value or rejected with an error.
+type Future struct {
+	once  sync.Once
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+func newFuture() *Future {
+	return &Future{done: make(chan struct{})}
+}
+
+// settle sets the outcome of the future, returning false if it was already
+// settled.
+func (f *Future) settle(value interface{}, err error) bool {
+	var settled bool
+	f.once.Do(func() {
+		f.value, f.err = value, err
+		settled = true
+		close(f.done)
+	})
+	return settled
+}
+
+// New returns a new Future which runs fn on a new goroutine, settling with
+// it's result. Panics raised by fn reject the future.
+func New(fn func() (interface{}, error)) *Future {
+	f := newFuture()
+	go func() {
+		var value interface{}
+		err := panics.Guard(func() error {
+			var ferr error
+			value, ferr = fn()
+			return ferr
+		})
+		f.settle(value, err)
+	}()
+	return f
+}
+
+// WithContext returns a new Future which runs fn with the context on a new
+// goroutine. The future is rejected with the context error if the context is
+// done before fn returns.
+func WithContext(ctx context.Context, fn func(context.Context) (interface{}, error)) *Future {
+	f := New(func() (interface{}, error) {
+		return fn(ctx)
+	})
+
+	out := newFuture()
+	go func() {
+		select {
+		case <-f.done:
+			out.settle(f.value, f.err)
+		case <-ctx.Done():
+			out.settle(nil, ctx.Err())
+		}
+	}()
+	return out
+}
+
+// Resolved returns a Future already resolved with the giving value.
+func Resolved(value interface{}) *Future {
+	f := newFuture()
+	f.settle(value, nil)
+	return f
+}
+
+// Rejected returns a Future already rejected with the giving error.
+func Rejected(err error) *Future {
+	f := newFuture()
+	f.settle(nil, err)
+	return f
+}
+
+// Done returns a channel which is closed once the future settles.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Settled returns true if the future has settled.
+func (f *Future) Settled() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Get blocks until the future settles, returning it's value and error.
+func (f *Future) Get() (interface{}, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// Await blocks until the future settles or the context is done, returning
+// the context error in the latter case.
+func (f *Future) Await(ctx context.Context) (interface{}, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Then returns a new Future settled with the result of fn called with the
+// value of this future once resolved. A rejection is passed through without
+// calling fn.
+func (f *Future) Then(fn func(interface{}) (interface{}, error)) *Future {
+	return f.chain(func(value interface{}, err error) (interface{}, error) {
+		if err != nil {
+			return nil, err
+		}
+		return fn(value)
+	})
+}
+
+// Catch returns a new Future settled with the result of fn called with the
+// error of this future once rejected, allowing recovery. A resolved value is
+// passed through without calling fn.
+func (f *Future) Catch(fn func(error) (interface{}, error)) *Future {
+	return f.chain(func(value interface{}, err error) (interface{}, error) {
+		if err == nil {
+			return value, nil
+		}
+		return fn(err)
+	})
+}
+
+// Finally returns a new Future which calls fn once this future settles and
+// then settles with the same outcome.
+func (f *Future) Finally(fn func()) *Future {
+	return f.chain(func(value interface{}, err error) (interface{}, error) {
+		fn()
+		return value, err
+	})
+}
+
+// Timeout returns a new Future which settles with the outcome of this future
+// or is rejected with ErrTimeout if it does not settle within the duration.
+func (f *Future) Timeout(d time.Duration) *Future {
+	out := newFuture()
+	go func() {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case <-f.done:
+			out.settle(f.value, f.err)
+		case <-timer.C:
+			out.settle(nil, ErrTimeout)
+		}
+	}()
+	return out
+}
+
+func (f *Future) chain(fn func(interface{}, error) (interface{}, error)) *Future {
+	return New(func() (interface{}, error) {
+		<-f.done
+		return fn(f.value, f.err)
+	})
+}
+
+//==============================================================================
+
+// Promise defines a Future which is settled by calling Resolve or Reject.
+type Promise struct {
+	*Future
+}
+
+// NewPromise returns a new instance of a Promise.
+func NewPromise() *Promise {
+	return &Promise{Future: newFuture()}
+}
+
+// Resolve settles the promise with the giving value, returning false if it
+// was already settled.
+func (p *Promise) Resolve(value interface{}) bool {
+	return p.settle(value, nil)
+}
+
+// Reject settles the promise with the giving error, returning false if it
+// was already settled.
+func (p *Promise) Reject(err error) bool {
+	return p.settle(nil, err)
+}
+
+//==============================================================================
+
+// Errors defines the list of errors of all futures rejected within Any.
+type Errors []error
+
+// Error implements the error interface.
+func (e Errors) Error() string {
+	var bu bytes.Buffer
+	bu.WriteString("all futures rejected: ")
+	for index, err := range e {
+		if index > 0 {
+			bu.WriteString("; ")
+		}
+		bu.WriteString(err.Error())
+	}
+	return bu.String()
+}
+
+// All returns a Future resolved with the values of all futures in order,
+// as a []interface{}, once all resolve. It is rejected with the first
+// rejection.
+func All(futures ...*Future) *Future {
+	out := newFuture()
+	values := make([]interface{}, len(futures))
+
+	var ml sync.Mutex
+	pending := len(futures)
+	if pending == 0 {
+		out.settle(values, nil)
+		return out
+	}
+
+	for index, f := range futures {
+		go func(index int, f *Future) {
+			value, err := f.Get()
+			if err != nil {
+				out.settle(nil, err)
+				return
+			}
+
+			ml.Lock()
+			values[index] = value
+			pending--
+			last := pending == 0
+			ml.Unlock()
+
+			if last {
+				out.settle(values, nil)
+			}
+		}(index, f)
+	}
+
+	return out
+}
+
+// Race returns a Future settled with the outcome of the first future to
+// settle. It is rejected with ErrNoFutures if none are provided.
+func Race(futures ...*Future) *Future {
+	out := newFuture()
+	if len(futures) == 0 {
+		out.settle(nil, ErrNoFutures)
+		return out
+	}
+
+	for _, f := range futures {
+		go func(f *Future) {
+			out.settle(f.Get())
+		}(f)
+	}
+
+	return out
+}
+
+// Any returns a Future resolved with the value of the first future to
+// resolve. It is rejected with Errors, in the order of the futures, if all
+// are rejected, and with ErrNoFutures if none are provided.
+func Any(futures ...*Future) *Future {
+	out := newFuture()
+	if len(futures) == 0 {
+		out.settle(nil, ErrNoFutures)
+		return out
+	}
+
+	var ml sync.Mutex
+	errs := make(Errors, len(futures))
+	pending := len(futures)
+
+	for index, f := range futures {
+		go func(index int, f *Future) {
+			value, err := f.Get()
+			if err == nil {
+				out.settle(value, nil)
+				return
+			}
+
+			ml.Lock()
+			errs[index] = err
+			pending--
+			last := pending == 0
+			ml.Unlock()
+
+			if last {
+				out.settle(nil, errs)
+			}
+		}(index, f)
+	}
+
+	return out
+}
@@ -0,0 +1,119 @@
+package promise_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/promise"
+)
+
+func TestChain(t *testing.T) {
+	var finalized bool
+
+	value, err := promise.New(func() (interface{}, error) {
+		return 2, nil
+	}).Then(func(v interface{}) (interface{}, error) {
+		return v.(int) * 10, nil
+	}).Then(func(v interface{}) (interface{}, error) {
+		return nil, errors.New("bad value")
+	}).Then(func(v interface{}) (interface{}, error) {
+		t.Fatalf("Should not have called Then after rejection")
+		return nil, nil
+	}).Catch(func(err error) (interface{}, error) {
+		return "recovered: " + err.Error(), nil
+	}).Finally(func() {
+		finalized = true
+	}).Get()
+
+	if err != nil || value != "recovered: bad value" {
+		t.Fatalf("Should have recovered from rejection: %+v %+v", value, err)
+	}
+
+	if !finalized {
+		t.Fatalf("Should have called Finally")
+	}
+
+	if _, err := promise.New(func() (interface{}, error) {
+		panic("boom")
+	}).Get(); err == nil {
+		t.Fatalf("Should have rejected future on panic")
+	}
+}
+
+func TestPromise(t *testing.T) {
+	p := promise.NewPromise()
+
+	if p.Settled() {
+		t.Fatalf("Should not have settled before resolve")
+	}
+
+	go p.Resolve("done")
+
+	value, err := p.Await(context.Background())
+	if err != nil || value != "done" {
+		t.Fatalf("Should have resolved promise: %+v %+v", value, err)
+	}
+
+	if p.Reject(errors.New("late")) {
+		t.Fatalf("Should not have settled promise twice")
+	}
+}
+
+func TestTimeoutAndContext(t *testing.T) {
+	slow := promise.NewPromise()
+
+	if _, err := slow.Timeout(10 * time.Millisecond).Get(); err != promise.ErrTimeout {
+		t.Fatalf("Should have timed out: %+v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f := promise.WithContext(ctx, func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return "late", nil
+	})
+	cancel()
+
+	if _, err := f.Get(); err != context.Canceled {
+		t.Fatalf("Should have rejected with context error: %+v", err)
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	delayed := func(d time.Duration, value interface{}, err error) *promise.Future {
+		return promise.New(func() (interface{}, error) {
+			time.Sleep(d)
+			return value, err
+		})
+	}
+
+	values, err := promise.All(delayed(20*time.Millisecond, 1, nil), promise.Resolved(2)).Get()
+	if err != nil || !reflect.DeepEqual(values, []interface{}{1, 2}) {
+		t.Fatalf("Should have resolved all values in order: %+v %+v", values, err)
+	}
+
+	bad := errors.New("bad")
+	if _, err := promise.All(promise.Resolved(1), promise.Rejected(bad)).Get(); err != bad {
+		t.Fatalf("Should have rejected with first rejection: %+v", err)
+	}
+
+	if value, _ := promise.Race(delayed(50*time.Millisecond, "slow", nil), delayed(0, "fast", nil)).Get(); value != "fast" {
+		t.Fatalf("Should have settled with fastest future: %+v", value)
+	}
+
+	if value, _ := promise.Any(promise.Rejected(bad), delayed(10*time.Millisecond, "ok", nil)).Get(); value != "ok" {
+		t.Fatalf("Should have resolved with first resolution: %+v", value)
+	}
+
+	_, err = promise.Any(promise.Rejected(bad), promise.Rejected(bad)).Get()
+	if errs, ok := err.(promise.Errors); !ok || len(errs) != 2 {
+		t.Fatalf("Should have rejected with all errors: %+v", err)
+	}
+
+	if _, err := promise.Race().Get(); err != promise.ErrNoFutures {
+		t.Fatalf("Should have rejected race without futures: %+v", err)
+	}
+}
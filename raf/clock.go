@@ -0,0 +1,172 @@
+package raf
+
+import "sync"
+
+// Mux defines a handler for using with RAF.
+type Mux func(float64)
+
+//==============================================================================
+
+// Clock defines a loop which calls it's handler on every animation frame,
+// using requestAnimationFrame in the browser (GopherJS or WebAssembly) and a
+// frame timer elsewhere, so the same loop runs in both.
+type Clock struct {
+	mux     Mux
+	ml      sync.Mutex
+	running bool
+	paused  bool
+	ticking bool
+	clockID int
+	frame   int
+}
+
+// New returns a new instance pointer of the Clock type.
+func New(m Mux) *Clock {
+	return &Clock{
+		mux:     m,
+		clockID: -1,
+	}
+}
+
+// Start registers the clock with the animation call loop. Calls all passed in
+// functions once the clock has being successfully registered.
+func (c *Clock) Start(f ...func()) {
+	c.ml.Lock()
+	if c.running {
+		c.ml.Unlock()
+		return
+	}
+
+	c.running = true
+	c.request()
+	c.ml.Unlock()
+
+	for _, fx := range f {
+		fx()
+	}
+}
+
+// Stop deregisters the clock and stops all loop calls and calls the passed in
+// functions. A frame already running completes, but no further frame is
+// requested.
+func (c *Clock) Stop(f ...func()) {
+	c.ml.Lock()
+	if !c.running {
+		c.ml.Unlock()
+		return
+	}
+
+	c.running = false
+	c.cancel()
+	c.ml.Unlock()
+
+	for _, fx := range f {
+		fx()
+	}
+}
+
+// Running returns true if the clock has been started and not stopped.
+func (c *Clock) Running() bool {
+	c.ml.Lock()
+	defer c.ml.Unlock()
+	return c.running
+}
+
+// Tick calls the clock handler with the frame timestamp and requests the
+// next frame, unless the clock is stopped or paused. Any frame already
+// requested is cancelled, so calling Tick directly drives the clock without
+// starting a second loop.
+func (c *Clock) Tick(f float64) {
+	c.ml.Lock()
+	c.cancel()
+	frame := c.frame
+	c.ml.Unlock()
+
+	c.tick(frame, f)
+}
+
+// tick runs the frame with the giving token, ignoring frames which were
+// cancelled or replaced after their timer already fired.
+func (c *Clock) tick(frame int, f float64) {
+	c.ml.Lock()
+	if frame != c.frame {
+		c.ml.Unlock()
+		return
+	}
+
+	c.clockID = -1
+	if !c.running || c.paused || c.ticking {
+		c.ml.Unlock()
+		return
+	}
+	c.ticking = true
+	c.ml.Unlock()
+
+	go func() {
+		c.mux(f)
+
+		c.ml.Lock()
+		c.ticking = false
+		c.request()
+		c.ml.Unlock()
+	}()
+}
+
+// Toggle switches the state of the clock from paused to resume and vise versa.
+func (c *Clock) Toggle() {
+	c.ml.Lock()
+	paused := c.paused
+	c.ml.Unlock()
+
+	if paused {
+		c.Resume()
+		return
+	}
+
+	c.Pause()
+}
+
+// Resume enables the clocks ticking if it has been paused.
+func (c *Clock) Resume() {
+	c.ml.Lock()
+	defer c.ml.Unlock()
+
+	c.paused = false
+	c.request()
+}
+
+// Pause disabbles the clocks ticking until resumed.
+func (c *Clock) Pause() {
+	c.ml.Lock()
+	defer c.ml.Unlock()
+
+	c.paused = true
+	c.cancel()
+}
+
+// request asks for the next frame if the clock should tick and none is
+// pending. The caller must hold c.ml.
+func (c *Clock) request() {
+	if !c.running || c.paused || c.ticking || c.clockID != -1 {
+		return
+	}
+
+	c.frame++
+	frame := c.frame
+	c.clockID = RequestAnimationFrame(func(f float64) {
+		c.tick(frame, f)
+	})
+}
+
+// cancel drops the pending frame if any and invalidates it's token, so a
+// frame whose timer already fired is ignored. The caller must hold c.ml.
+func (c *Clock) cancel() {
+	c.frame++
+
+	if c.clockID == -1 {
+		return
+	}
+
+	CancelAnimationFrame(c.clockID)
+	c.clockID = -1
+}
@@ -1,146 +1,67 @@
+//go:build !(js && wasm)
+// +build !js !wasm
+
 package raf
 
 import (
 	"math"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/go-humble/detect"
 	"github.com/gopherjs/gopherjs/js"
 )
 
-// Mux defines a handler for using with RAF.
-type Mux func(float64)
-
-//==============================================================================
-
-// Clock defines an interface which exposes methods which allow a timeloop run.
-type Clock struct {
-	mux     Mux
-	paused  int64
-	clockID int64
-}
-
-// New returns a new instance pointer of the Clock type.
-func New(m Mux) *Clock {
-	return &Clock{
-		mux:     m,
-		clockID: -1,
-		paused:  -1,
-	}
-}
-
-// Start registers the clock with the animation call loop. Calls all passed in
-// functions once the clock has being successfully registered.
-func (c *Clock) Start(f ...func()) {
-	if atomic.LoadInt64(&c.clockID) != -1 {
-		return
-	}
+var minElapse = 16 * time.Millisecond
 
-	atomic.StoreInt64(&c.clockID, int64(RequestAnimationFrame(c.Tick, f...)))
-}
+var started = time.Now()
 
-// Stop deregisters the clock and stops all loop calls and calls the passed in
-// functions.
-func (c *Clock) Stop(f ...func()) {
-	if atomic.LoadInt64(&c.clockID) == -1 {
-		return
-	}
-
-	CancelAnimationFrame(int(atomic.LoadInt64(&c.clockID)), f...)
-	atomic.StoreInt64(&c.clockID, -1)
+var tickers = struct {
+	m       sync.Mutex
+	next    int
+	tickers map[int]*time.Timer
+}{
+	tickers: make(map[int]*time.Timer),
 }
 
-// Tick enables the clocks ticking if it has been paused.
-func (c *Clock) Tick(f float64) {
-	if atomic.LoadInt64(&c.paused) != -1 {
-		return
-	}
-
-	go func() {
-		c.mux(f)
-
-		atomic.StoreInt64(&c.clockID, int64(RequestAnimationFrame(c.Tick)))
-	}()
-}
+// Now returns the current high resolution time in milliseconds, using
+// performance.now in the browser and the time elapsed since the program
+// started otherwise.
+func Now() float64 {
+	if detect.IsBrowser() {
+		if perf := js.Global.Get("performance"); perf != js.Undefined && perf.Get("now") != js.Undefined {
+			return perf.Call("now").Float()
+		}
 
-// Toggle switches the state of the clock from paused to resume and vise versa.
-func (c *Clock) Toggle() {
-	if atomic.LoadInt64(&c.paused) < 0 {
-		atomic.StoreInt64(&c.paused, 1)
-		return
+		return js.Global.Get("Date").New().Call("getTime").Float()
 	}
 
-	atomic.StoreInt64(&c.paused, -1)
-}
-
-// Resume enables the clocks ticking if it has been paused.
-func (c *Clock) Resume() {
-	atomic.StoreInt64(&c.paused, -1)
-}
-
-// Pause disabbles the clocks ticking if it has been paused.
-func (c *Clock) Pause() {
-	atomic.StoreInt64(&c.paused, 1)
-}
-
-//==============================================================================
-
-var minElapse = 16 * time.Millisecond
-
-var tickers = struct {
-	m       sync.Mutex
-	tickers []*ticker
-}{}
-
-type ticker struct {
-	id   int
-	fn   Mux
-	kill bool
-	last float64
+	return float64(time.Since(started)) / float64(time.Millisecond)
 }
 
 // RequestAnimationFrame provides a cover for RAF using the js
-// api for requestAnimationFrame.
+// api for requestAnimationFrame. Outside the browser the handler is
+// called once after a frame duration of ~16ms, as the browser would.
 func RequestAnimationFrame(r Mux, f ...func()) int {
 	if !detect.IsBrowser() {
-		var tLen int
-
 		tickers.m.Lock()
-		{
-			tLen = len(tickers.tickers)
-
-			newticker := &ticker{
-				id:   tLen,
-				fn:   r,
-				last: float64(time.Now().Unix()),
-			}
-
-			go func() {
-				for {
-					if newticker.kill {
-						break
-					}
-
-					now := float64(time.Now().Unix())
-					elapsed := math.Max(0, now-newticker.last)
-					newticker.last = now
-
-					tickerElapsed := time.Duration(float64(minElapse) + elapsed)
+		id := tickers.next
+		tickers.next++
 
-					tk := time.NewTicker(tickerElapsed)
-					<-tk.C
+		tickers.tickers[id] = time.AfterFunc(minElapse, func() {
+			tickers.m.Lock()
+			delete(tickers.tickers, id)
+			tickers.m.Unlock()
 
-					newticker.fn(tickerElapsed.Seconds())
-				}
-			}()
+			r(Now())
+		})
+		tickers.m.Unlock()
 
-			tickers.tickers = append(tickers.tickers, newticker)
+		for _, fx := range f {
+			fx()
 		}
-		tickers.m.Unlock()
 
-		return tLen
+		return id
 	}
 
 	id := js.Global.Call("requestAnimationFrame", r).Int()
@@ -157,18 +78,16 @@ func RequestAnimationFrame(r Mux, f ...func()) int {
 func CancelAnimationFrame(id int, f ...func()) {
 	if !detect.IsBrowser() {
 		tickers.m.Lock()
-		{
-			tLen := len(tickers.tickers)
-			if id >= tLen {
-				tickers.m.Unlock()
-				return
-			}
-
-			tm := tickers.tickers[id]
-			tm.kill = true
+		if timer, ok := tickers.tickers[id]; ok {
+			timer.Stop()
+			delete(tickers.tickers, id)
 		}
 		tickers.m.Unlock()
 
+		for _, fx := range f {
+			fx()
+		}
+
 		return
 	}
 
@@ -218,5 +137,3 @@ func rafPolyfill() {
 		})
 	}
 }
-
-//==============================================================================
@@ -1,6 +1,7 @@
 package raf_test
 
 import (
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -8,17 +9,161 @@ import (
 )
 
 func TestRAF(t *testing.T) {
-	var count int
+	var count int32
 
-	id := raf.RequestAnimationFrame(func(df float64) {
-		count++
+	raf.RequestAnimationFrame(func(df float64) {
+		atomic.AddInt32(&count, 1)
 	})
 
 	<-time.After(1 * time.Second)
+
+	if atomic.LoadInt32(&count) != 1 {
+		t.Fatalf("Expected single call for animation frame: %d", count)
+	}
+	t.Logf("Expected single call for animation frame: %d", count)
+
+	id := raf.RequestAnimationFrame(func(df float64) {
+		atomic.AddInt32(&count, 1)
+	})
 	raf.CancelAnimationFrame(id)
 
-	if count > 62 {
-		t.Fatalf("Expected 61 or 62 count for animation calls: %d", count)
+	<-time.After(100 * time.Millisecond)
+
+	if atomic.LoadInt32(&count) != 1 {
+		t.Fatalf("Expected no call for cancelled animation frame: %d", count)
+	}
+	t.Logf("Expected no call for cancelled animation frame: %d", count)
+}
+
+func TestScheduler(t *testing.T) {
+	var count int32
+	done := make(chan float64, 1)
+
+	s := raf.NewScheduler()
+	for i := 0; i < 5; i++ {
+		s.Schedule(func(stamp float64) {
+			atomic.AddInt32(&count, 1)
+		})
+	}
+
+	s.Schedule(func(stamp float64) {
+		done <- stamp
+	})
+
+	select {
+	case stamp := <-done:
+		if stamp <= 0 || stamp > raf.Now() {
+			t.Fatalf("Expected frame timestamp before now: %f", stamp)
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Expected scheduled callbacks to run within a frame")
+	}
+
+	if atomic.LoadInt32(&count) != 5 || s.Pending() != 0 {
+		t.Fatalf("Expected all callbacks to run in one frame: %d", count)
+	}
+	t.Logf("Expected all callbacks to run in one frame: %d", count)
+
+	s.Schedule(func(stamp float64) {
+		atomic.AddInt32(&count, 1)
+	})
+	s.Cancel()
+
+	<-time.After(100 * time.Millisecond)
+
+	if atomic.LoadInt32(&count) != 5 {
+		t.Fatalf("Expected no call after cancel: %d", count)
+	}
+	t.Logf("Expected no call after cancel: %d", count)
+}
+
+func TestClock(t *testing.T) {
+	var count int32
+
+	clock := raf.New(func(df float64) {
+		atomic.AddInt32(&count, 1)
+	})
+
+	clock.Start()
+	<-time.After(200 * time.Millisecond)
+
+	if atomic.LoadInt32(&count) < 3 {
+		t.Fatalf("Expected clock to tick every frame: %d", count)
+	}
+	t.Logf("Expected clock to tick every frame: %d", count)
+
+	clock.Pause()
+	<-time.After(50 * time.Millisecond)
+	paused := atomic.LoadInt32(&count)
+	<-time.After(100 * time.Millisecond)
+
+	if atomic.LoadInt32(&count) != paused {
+		t.Fatalf("Expected no ticks while paused: %d", count)
+	}
+	t.Logf("Expected no ticks while paused: %d", count)
+
+	clock.Resume()
+	<-time.After(100 * time.Millisecond)
+
+	if atomic.LoadInt32(&count) == paused {
+		t.Fatalf("Expected ticks after resume: %d", count)
+	}
+	t.Logf("Expected ticks after resume: %d", count)
+
+	clock.Stop()
+	<-time.After(50 * time.Millisecond)
+	stopped := atomic.LoadInt32(&count)
+	<-time.After(100 * time.Millisecond)
+
+	if atomic.LoadInt32(&count) != stopped || clock.Running() {
+		t.Fatalf("Expected no ticks after stop: %d", count)
+	}
+	t.Logf("Expected no ticks after stop: %d", count)
+}
+
+func TestClockPauseResume(t *testing.T) {
+	var count int32
+
+	clock := raf.New(func(df float64) {
+		atomic.AddInt32(&count, 1)
+	})
+
+	clock.Start()
+	defer clock.Stop()
+
+	// cycle while frames fire, so pauses land between a frame timer firing
+	// and it's tick running.
+	deadline := time.Now().Add(300 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		clock.Pause()
+		clock.Resume()
+	}
+
+	atomic.StoreInt32(&count, 0)
+	<-time.After(500 * time.Millisecond)
+
+	// a single loop ticks about 31 times in 500ms.
+	if ticks := atomic.LoadInt32(&count); ticks < 15 || ticks > 45 {
+		t.Fatalf("Expected a single loop after pause and resume: %d", ticks)
+	}
+	t.Logf("Expected a single loop after pause and resume: %d", count)
+
+	clock.Stop()
+	clock.Start()
+	clock.Stop()
+	clock.Start()
+
+	// a frame which fires after it was replaced behaves as a direct tick.
+	for i := 0; i < 20; i++ {
+		clock.Tick(raf.Now())
+		<-time.After(5 * time.Millisecond)
+	}
+
+	atomic.StoreInt32(&count, 0)
+	<-time.After(500 * time.Millisecond)
+
+	if ticks := atomic.LoadInt32(&count); ticks < 15 || ticks > 45 {
+		t.Fatalf("Expected a single loop after stop, start and stale frames: %d", ticks)
 	}
-	t.Logf("Expected 61 or 62 count for animation calls: %d", count)
+	t.Logf("Expected a single loop after stop, start and stale frames: %d", count)
 }
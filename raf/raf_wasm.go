@@ -0,0 +1,128 @@
+//go:build js && wasm
+// +build js,wasm
+
+package raf
+
+import (
+	"math"
+	"sync"
+	"syscall/js"
+)
+
+var frames = struct {
+	m     sync.Mutex
+	funcs map[int]js.Func
+}{
+	funcs: make(map[int]js.Func),
+}
+
+// Now returns the current high resolution time in milliseconds using
+// performance.now, falling back to Date.now.
+func Now() float64 {
+	if perf := js.Global().Get("performance"); perf.Truthy() && perf.Get("now").Truthy() {
+		return perf.Call("now").Float()
+	}
+
+	return js.Global().Get("Date").Call("now").Float()
+}
+
+// RequestAnimationFrame provides a cover for RAF using the js
+// api for requestAnimationFrame.
+func RequestAnimationFrame(r Mux, f ...func()) int {
+	var id int
+	var fn js.Func
+
+	frames.m.Lock()
+	defer frames.m.Unlock()
+
+	fn = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		frames.m.Lock()
+		delete(frames.funcs, id)
+		frames.m.Unlock()
+		fn.Release()
+
+		var stamp float64
+		if len(args) > 0 {
+			stamp = args[0].Float()
+		}
+
+		r(stamp)
+		return nil
+	})
+
+	id = js.Global().Call("requestAnimationFrame", fn).Int()
+	frames.funcs[id] = fn
+
+	for _, fx := range f {
+		fx()
+	}
+
+	return id
+}
+
+// CancelAnimationFrame provides a cover for RAF using the
+// js api cancelAnimationFrame.
+func CancelAnimationFrame(id int, f ...func()) {
+	js.Global().Call("cancelAnimationFrame", id)
+
+	frames.m.Lock()
+	if fn, ok := frames.funcs[id]; ok {
+		fn.Release()
+		delete(frames.funcs, id)
+	}
+	frames.m.Unlock()
+
+	for _, fx := range f {
+		fx()
+	}
+}
+
+//==============================================================================
+
+func init() {
+	rafPolyfill()
+}
+
+func rafPolyfill() {
+	window := js.Global()
+	if window.Get("requestAnimationFrame").Truthy() {
+		return
+	}
+
+	vendors := []string{"ms", "moz", "webkit", "o"}
+	for _, vendor := range vendors {
+		if request := window.Get(vendor + "RequestAnimationFrame"); request.Truthy() {
+			window.Set("requestAnimationFrame", request)
+
+			cancel := window.Get(vendor + "CancelAnimationFrame")
+			if !cancel.Truthy() {
+				cancel = window.Get(vendor + "CancelRequestAnimationFrame")
+			}
+			window.Set("cancelAnimationFrame", cancel)
+			return
+		}
+	}
+
+	lastTime := 0.0
+	window.Set("requestAnimationFrame", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		callback := args[0]
+		currTime := Now()
+		timeToCall := math.Max(0, 16-(currTime-lastTime))
+		stamp := currTime + timeToCall
+		lastTime = stamp
+
+		var timeout js.Func
+		timeout = js.FuncOf(func(js.Value, []js.Value) interface{} {
+			timeout.Release()
+			callback.Invoke(stamp)
+			return nil
+		})
+
+		return window.Call("setTimeout", timeout, timeToCall)
+	}))
+
+	window.Set("cancelAnimationFrame", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		window.Call("clearTimeout", args[0])
+		return nil
+	}))
+}
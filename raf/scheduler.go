@@ -0,0 +1,68 @@
+package raf
+
+import "sync"
+
+// Scheduler batches callbacks into animation frames, requesting a single
+// frame for all callbacks scheduled before it runs. Callbacks scheduled
+// while a frame is running are deferred to the next frame.
+type Scheduler struct {
+	ml      sync.Mutex
+	frameID int
+	pending bool
+	queue   []Mux
+}
+
+// NewScheduler returns a new instance of a Scheduler.
+func NewScheduler() *Scheduler {
+	return &Scheduler{frameID: -1}
+}
+
+// Schedule adds the giving handler to be called once on the next frame with
+// the frame timestamp.
+func (s *Scheduler) Schedule(fn Mux) {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	s.queue = append(s.queue, fn)
+
+	if !s.pending {
+		s.pending = true
+		s.frameID = RequestAnimationFrame(s.frame)
+	}
+}
+
+// Pending returns the total of callbacks waiting for the next frame.
+func (s *Scheduler) Pending() int {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+	return len(s.queue)
+}
+
+// Cancel drops all callbacks waiting for the next frame and cancels the
+// requested frame.
+func (s *Scheduler) Cancel() {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	if s.pending {
+		CancelAnimationFrame(s.frameID)
+	}
+
+	s.queue = nil
+	s.pending = false
+	s.frameID = -1
+}
+
+// frame runs all callbacks queued before the frame began.
+func (s *Scheduler) frame(stamp float64) {
+	s.ml.Lock()
+	queue := s.queue
+	s.queue = nil
+	s.pending = false
+	s.frameID = -1
+	s.ml.Unlock()
+
+	for _, fn := range queue {
+		fn(stamp)
+	}
+}
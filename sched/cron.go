@@ -0,0 +1,279 @@
+package sched
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errors.
+var (
+	ErrInvalidCronExpr = errors.New("cron expression must have 5 fields")
+	ErrInvalidEvery    = errors.New("@every duration must be greater than zero")
+)
+
+// Schedule defines an interface which returns the next activation time of a
+// job after a provided time.
+type Schedule interface {
+	Next(time.Time) time.Time
+}
+
+//==============================================================================
+
+// every implements a Schedule which activates at fixed intervals.
+type every struct {
+	interval time.Duration
+}
+
+// Every returns a Schedule which activates on every giving interval.
+func Every(d time.Duration) Schedule {
+	if d <= 0 {
+		panic(ErrInvalidEvery)
+	}
+
+	return every{interval: d}
+}
+
+// Next returns the next activation time for the interval.
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(e.interval)
+}
+
+//==============================================================================
+
+// cronBounds defines the lower and upper limit of a cron field.
+type cronBounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteBounds = cronBounds{min: 0, max: 59}
+	hourBounds   = cronBounds{min: 0, max: 23}
+	domBounds    = cronBounds{min: 1, max: 31}
+	monthBounds  = cronBounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowBounds = cronBounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSchedule implements a Schedule from a parsed cron expression, where each
+// field is stored as a bitset of allowed values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+// MustCron returns a Schedule for the giving cron expression, panicking if it
+// fails to parse.
+func MustCron(expr string) Schedule {
+	sch, err := Cron(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return sch
+}
+
+// Cron returns a Schedule from the standard 5 field cron expression format:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Fields support '*', lists (1,2), ranges (1-5), steps (*/15, 1-30/5) and
+// month and weekday names (jan, mon). The descriptors @yearly, @monthly,
+// @weekly, @daily, @hourly and "@every <duration>" are also supported.
+func Cron(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+
+	if strings.HasPrefix(expr, "@every ") {
+		dur, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+		if err != nil {
+			return nil, err
+		}
+
+		if dur <= 0 {
+			return nil, ErrInvalidEvery
+		}
+
+		return every{interval: dur}, nil
+	}
+
+	if desc, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = desc
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, ErrInvalidCronExpr
+	}
+
+	var err error
+	var cs cronSchedule
+
+	if cs.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+
+	if cs.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+
+	if cs.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, err
+	}
+
+	if cs.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+
+	if cs.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, err
+	}
+
+	// Allow 7 as an alias of sunday.
+	if cs.dow&(1<<7) != 0 {
+		cs.dow |= 1
+	}
+
+	cs.domStar = fields[2] == "*" || fields[2] == "?"
+	cs.dowStar = fields[4] == "*" || fields[4] == "?"
+
+	return cs, nil
+}
+
+// Next returns the next time after t which matches the cron expression.
+// It returns a zero time if no match is found within five years.
+func (cs cronSchedule) Next(t time.Time) time.Time {
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if cs.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !cs.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if cs.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if cs.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches follows cron semantics where a day matches either the
+// day-of-month or day-of-week field when both are restricted.
+func (cs cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := cs.dom&(1<<uint(t.Day())) != 0
+	dowMatch := cs.dow&(1<<uint(t.Weekday())) != 0
+
+	if cs.domStar || cs.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// parseField parses a single comma separated cron field into a bitset.
+func parseField(field string, bounds cronBounds) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if index := strings.Index(part, "/"); index != -1 {
+			val, err := strconv.Atoi(part[index+1:])
+			if err != nil || val <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", field)
+			}
+
+			step = val
+			part = part[:index]
+		}
+
+		start, end := bounds.min, bounds.max
+
+		switch {
+		case part == "*" || part == "?":
+		case strings.Contains(part, "-"):
+			pieces := strings.SplitN(part, "-", 2)
+
+			var err error
+			if start, err = parseValue(pieces[0], bounds); err != nil {
+				return 0, err
+			}
+
+			if end, err = parseValue(pieces[1], bounds); err != nil {
+				return 0, err
+			}
+		default:
+			val, err := parseValue(part, bounds)
+			if err != nil {
+				return 0, err
+			}
+
+			start = val
+
+			// A single value with a step runs to the upper bound, i.e 5/15.
+			end = val
+			if step > 1 {
+				end = bounds.max
+			}
+		}
+
+		if start > end {
+			return 0, fmt.Errorf("invalid range in %q", field)
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+// parseValue parses a single value or name within the giving bounds.
+func parseValue(val string, bounds cronBounds) (int, error) {
+	if named, ok := bounds.names[strings.ToLower(val)]; ok {
+		return named, nil
+	}
+
+	num, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cron value %q", val)
+	}
+
+	if num < bounds.min || num > bounds.max {
+		return 0, fmt.Errorf("cron value %d out of range [%d-%d]", num, bounds.min, bounds.max)
+	}
+
+	return num, nil
+}
@@ -0,0 +1,273 @@
+// Package sched provides a cron-style job scheduler supporting cron expressions
+// and intervals, with per-job overlap policies, jitter and context cancellation.
+package sched
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// errors.
+var (
+	ErrJobExists     = errors.New("job with given name already exists")
+	ErrJobNotFound   = errors.New("job with given name not found")
+	ErrInvalidJob    = errors.New("job requires a name, schedule and function")
+	ErrSchedulerDone = errors.New("scheduler already stopped")
+)
+
+// Overlap defines the policy applied when a job is due while a previous run of
+// the same job is still executing.
+type Overlap int
+
+// overlap policies.
+const (
+	// AllowOverlap runs the job concurrently with any previous run.
+	AllowOverlap Overlap = iota
+
+	// SkipOverlap skips the activation if a previous run is still executing.
+	SkipOverlap
+
+	// WaitOverlap delays the activation until the previous run finishes.
+	WaitOverlap
+)
+
+// Event defines the details of a giving job activation delivered to
+// scheduler listeners.
+type Event struct {
+	Job      string
+	Time     time.Time
+	Duration time.Duration
+	Skipped  bool
+	Err      error
+}
+
+// Listener defines a function type which receives Events for all job
+// activations, which is the means of feeding fire events into other
+// systems like pub nodes.
+type Listener func(Event)
+
+// Job defines a unit of work to be run by the Scheduler based on it's Schedule.
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Overlap  Overlap
+
+	// Jitter adds a random delay between zero and the giving value to each
+	// activation, to avoid multiple jobs firing at the exact same time.
+	Jitter time.Duration
+
+	Do func(context.Context) error
+}
+
+// Scheduler runs registered jobs according to their schedules until stopped
+// or it's parent context is cancelled.
+type Scheduler struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	listeners []Listener
+	waiter    sync.WaitGroup
+	ml        sync.Mutex
+	jobs      map[string]*entry
+}
+
+// New returns a new instance of a Scheduler whose jobs are all cancelled once
+// the provided context is done. All listeners receive an Event for each
+// job activation.
+func New(ctx context.Context, listeners ...Listener) *Scheduler {
+	var sc Scheduler
+	sc.listeners = listeners
+	sc.jobs = make(map[string]*entry)
+	sc.ctx, sc.cancel = context.WithCancel(ctx)
+	return &sc
+}
+
+// Add registers the giving job with the scheduler and starts it's activation
+// loop.
+func (s *Scheduler) Add(job Job) error {
+	if job.Name == "" || job.Schedule == nil || job.Do == nil {
+		return ErrInvalidJob
+	}
+
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	if s.ctx.Err() != nil {
+		return ErrSchedulerDone
+	}
+
+	if _, ok := s.jobs[job.Name]; ok {
+		return ErrJobExists
+	}
+
+	jctx, cancel := context.WithCancel(s.ctx)
+
+	en := &entry{job: job, cancel: cancel, sched: s}
+	s.jobs[job.Name] = en
+
+	s.waiter.Add(1)
+	go en.run(jctx)
+
+	return nil
+}
+
+// Remove stops and removes the job with the giving name. In-flight runs of
+// the job have their context cancelled.
+func (s *Scheduler) Remove(name string) error {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	en, ok := s.jobs[name]
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	en.cancel()
+	delete(s.jobs, name)
+	return nil
+}
+
+// Jobs returns the names of all registered jobs.
+func (s *Scheduler) Jobs() []string {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Next returns the next activation time of the giving job.
+func (s *Scheduler) Next(name string) (time.Time, error) {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	en, ok := s.jobs[name]
+	if !ok {
+		return time.Time{}, ErrJobNotFound
+	}
+
+	return en.nextTime(), nil
+}
+
+// Stop cancels all jobs and waits for all in-flight runs to finish.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.waiter.Wait()
+}
+
+// notify delivers the giving event to all listeners.
+func (s *Scheduler) notify(ev Event) {
+	for _, listener := range s.listeners {
+		listener(ev)
+	}
+}
+
+//==============================================================================
+
+// entry holds the running state of a registered job.
+type entry struct {
+	job    Job
+	sched  *Scheduler
+	cancel context.CancelFunc
+	ml     sync.Mutex
+	next   time.Time
+	runs   sync.WaitGroup
+	active int
+	last   chan struct{}
+}
+
+func (e *entry) nextTime() time.Time {
+	e.ml.Lock()
+	defer e.ml.Unlock()
+	return e.next
+}
+
+// run loops through activations of the job until the context is cancelled.
+func (e *entry) run(ctx context.Context) {
+	defer e.sched.waiter.Done()
+	defer e.runs.Wait()
+
+	now := time.Now()
+	for {
+		next := e.job.Schedule.Next(now)
+		if next.IsZero() {
+			return
+		}
+
+		e.ml.Lock()
+		e.next = next
+		e.ml.Unlock()
+
+		delay := next.Sub(time.Now())
+		if e.job.Jitter > 0 {
+			delay += time.Duration(rand.Int63n(int64(e.job.Jitter)))
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case now = <-timer.C:
+		}
+
+		e.fire(ctx, now)
+	}
+}
+
+// fire runs the job according to it's overlap policy.
+func (e *entry) fire(ctx context.Context, at time.Time) {
+	e.ml.Lock()
+	active, last := e.active, e.last
+	if active > 0 && e.job.Overlap == SkipOverlap {
+		e.ml.Unlock()
+		e.sched.notify(Event{Job: e.job.Name, Time: at, Skipped: true})
+		return
+	}
+
+	e.active++
+	e.ml.Unlock()
+
+	if active > 0 && e.job.Overlap == WaitOverlap {
+		select {
+		case <-last:
+		case <-ctx.Done():
+			e.ml.Lock()
+			e.active--
+			e.ml.Unlock()
+			return
+		}
+	}
+
+	done := make(chan struct{})
+
+	e.ml.Lock()
+	e.last = done
+	e.ml.Unlock()
+
+	e.runs.Add(1)
+	go func() {
+		defer e.runs.Done()
+		defer close(done)
+
+		start := time.Now()
+		err := e.job.Do(ctx)
+
+		e.ml.Lock()
+		e.active--
+		e.ml.Unlock()
+
+		e.sched.notify(Event{
+			Job:      e.job.Name,
+			Time:     at,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+	}()
+}
@@ -0,0 +1,154 @@
+package sched_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/sched"
+)
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2018, time.January, 16, 10, 20, 30, 0, time.UTC)
+
+	cases := map[string]time.Time{
+		"*/15 * * * *":      time.Date(2018, time.January, 16, 10, 30, 0, 0, time.UTC),
+		"0 12 * * *":        time.Date(2018, time.January, 16, 12, 0, 0, 0, time.UTC),
+		"5 4 1 feb *":       time.Date(2018, time.February, 1, 4, 5, 0, 0, time.UTC),
+		"0 0 * * sun":       time.Date(2018, time.January, 21, 0, 0, 0, 0, time.UTC),
+		"30 9 * * 1-5":      time.Date(2018, time.January, 17, 9, 30, 0, 0, time.UTC),
+		"@hourly":           time.Date(2018, time.January, 16, 11, 0, 0, 0, time.UTC),
+		"0 0 1,15 * 0":      time.Date(2018, time.January, 21, 0, 0, 0, 0, time.UTC),
+		"@every 90s":        base.Add(90 * time.Second),
+		"0 0 29 feb *":      time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"10-40/10 10 * * *": time.Date(2018, time.January, 16, 10, 30, 0, 0, time.UTC),
+	}
+
+	for expr, expected := range cases {
+		sch, err := sched.Cron(expr)
+		if err != nil {
+			t.Fatalf("Should have parsed cron expression %q: %+q", expr, err)
+		}
+
+		if next := sch.Next(base); !next.Equal(expected) {
+			t.Fatalf("Should have matched next time for %q: expected %s got %s", expr, expected, next)
+		}
+	}
+	t.Logf("Should have matched next time for all expressions")
+}
+
+func TestCronInvalid(t *testing.T) {
+	for _, expr := range []string{"* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "@every -1s"} {
+		if _, err := sched.Cron(expr); err == nil {
+			t.Fatalf("Should have failed to parse cron expression %q", expr)
+		}
+	}
+	t.Logf("Should have failed to parse invalid expressions")
+}
+
+func TestScheduler(t *testing.T) {
+	var count, events int64
+
+	sc := sched.New(context.Background(), func(ev sched.Event) {
+		atomic.AddInt64(&events, 1)
+	})
+
+	if err := sc.Add(sched.Job{
+		Name:     "counter",
+		Schedule: sched.Every(10 * time.Millisecond),
+		Do: func(ctx context.Context) error {
+			atomic.AddInt64(&count, 1)
+			return nil
+		},
+	}); err != nil {
+		t.Fatalf("Should have added job: %+q", err)
+	}
+
+	if err := sc.Add(sched.Job{Name: "counter", Schedule: sched.Every(time.Second), Do: func(context.Context) error { return nil }}); err != sched.ErrJobExists {
+		t.Fatalf("Should have failed to add duplicate job")
+	}
+
+	<-time.After(105 * time.Millisecond)
+	sc.Stop()
+
+	if total := atomic.LoadInt64(&count); total < 5 {
+		t.Fatalf("Should have run job multiple times: %d", total)
+	}
+
+	if atomic.LoadInt64(&events) != atomic.LoadInt64(&count) {
+		t.Fatalf("Should have received event for every run")
+	}
+	t.Logf("Should have run job multiple times")
+
+	if err := sc.Add(sched.Job{Name: "late", Schedule: sched.Every(time.Second), Do: func(context.Context) error { return nil }}); err != sched.ErrSchedulerDone {
+		t.Fatalf("Should have failed to add job to stopped scheduler")
+	}
+}
+
+func TestSchedulerSkipOverlap(t *testing.T) {
+	var skipped, runs int64
+
+	sc := sched.New(context.Background(), func(ev sched.Event) {
+		if ev.Skipped {
+			atomic.AddInt64(&skipped, 1)
+			return
+		}
+		atomic.AddInt64(&runs, 1)
+	})
+
+	sc.Add(sched.Job{
+		Name:     "slow",
+		Overlap:  sched.SkipOverlap,
+		Schedule: sched.Every(5 * time.Millisecond),
+		Do: func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+			case <-time.After(50 * time.Millisecond):
+			}
+			return nil
+		},
+	})
+
+	<-time.After(80 * time.Millisecond)
+	sc.Stop()
+
+	if atomic.LoadInt64(&skipped) == 0 {
+		t.Fatalf("Should have skipped overlapping activations")
+	}
+
+	if total := atomic.LoadInt64(&runs); total > 2 {
+		t.Fatalf("Should have at most two completed runs: %d", total)
+	}
+	t.Logf("Should have skipped overlapping activations")
+}
+
+func TestSchedulerWaitOverlapCancel(t *testing.T) {
+	var calls int64
+	release := make(chan struct{})
+
+	sc := sched.New(context.Background())
+	sc.Add(sched.Job{
+		Name:     "slow",
+		Overlap:  sched.WaitOverlap,
+		Schedule: sched.Every(5 * time.Millisecond),
+		Do: func(ctx context.Context) error {
+			if atomic.AddInt64(&calls, 1) == 1 {
+				<-release
+			}
+			return nil
+		},
+	})
+
+	<-time.After(30 * time.Millisecond)
+	sc.Remove("slow")
+
+	<-time.After(20 * time.Millisecond)
+	close(release)
+	sc.Stop()
+
+	if total := atomic.LoadInt64(&calls); total != 1 {
+		t.Fatalf("Should not have started waiting activation after removal: %d", total)
+	}
+	t.Logf("Should have abandoned waiting activation after removal")
+}
@@ -0,0 +1,184 @@
+// Package boltstore implements the store.Store interface on top of a boltdb
+// file, keeping all entries in a single bucket.
+package boltstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/influx6/faux/store"
+)
+
+// Store implements the store.Store interface using boltdb. Each value is
+// prefixed with it's expiration time as 8 big-endian bytes of unix
+// nanoseconds, where zero never expires.
+type Store struct {
+	db     *bolt.DB
+	bucket []byte
+	owned  bool
+}
+
+// Open returns a new instance of a Store using the boltdb file at the giving
+// path, creating it if missing. The database is closed when the Store is.
+func Open(path string, bucket string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{
+		Timeout: 30 * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := New(db, bucket)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	st.owned = true
+	return st, nil
+}
+
+// New returns a new instance of a Store using the bucket of an already opened
+// database, creating the bucket if missing. Closing the Store does not close
+// the database.
+func New(db *bolt.DB, bucket string) (*Store, error) {
+	name := []byte(bucket)
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, cerr := tx.CreateBucketIfNotExists(name)
+		return cerr
+	}); err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db, bucket: name}, nil
+}
+
+// Get implements the store.Store interface.
+func (s *Store) Get(key string) ([]byte, error) {
+	var value []byte
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(s.bucket).Get([]byte(key))
+		if data == nil {
+			return store.ErrNotFound
+		}
+
+		val, ok := decode(data, time.Now())
+		if !ok {
+			return store.ErrNotFound
+		}
+
+		value = make([]byte, len(val))
+		copy(value, val)
+		return nil
+	})
+
+	return value, err
+}
+
+// Set implements the store.Store interface.
+func (s *Store) Set(key string, value []byte, ttl time.Duration) error {
+	var expires int64
+	if ttl > 0 {
+		expires = time.Now().Add(ttl).UnixNano()
+	}
+
+	data := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(data, uint64(expires))
+	copy(data[8:], value)
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Put([]byte(key), data)
+	})
+}
+
+// Delete implements the store.Store interface.
+func (s *Store) Delete(key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Delete([]byte(key))
+	})
+}
+
+// Iterate implements the store.Store interface. Entries are visited in key
+// order within a read transaction, so fn must not modify the store.
+func (s *Store) Iterate(prefix string, fn store.IterateFunc) error {
+	pre := []byte(prefix)
+
+	return s.db.View(func(tx *bolt.Tx) error {
+		now := time.Now()
+		cursor := tx.Bucket(s.bucket).Cursor()
+
+		for key, data := cursor.Seek(pre); key != nil && bytes.HasPrefix(key, pre); key, data = cursor.Next() {
+			val, ok := decode(data, now)
+			if !ok {
+				continue
+			}
+
+			value := make([]byte, len(val))
+			copy(value, val)
+
+			if err := fn(string(key), value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Expire removes all expired entries, returning the total removed.
+func (s *Store) Expire() (int, error) {
+	var total int
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		now := time.Now()
+		bucket := tx.Bucket(s.bucket)
+
+		var expired [][]byte
+		if err := bucket.ForEach(func(key, data []byte) error {
+			if _, ok := decode(data, now); !ok {
+				expired = append(expired, append([]byte(nil), key...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, key := range expired {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+
+		total = len(expired)
+		return nil
+	})
+
+	return total, err
+}
+
+// Close implements the store.Store interface.
+func (s *Store) Close() error {
+	if s.owned {
+		return s.db.Close()
+	}
+	return nil
+}
+
+// decode returns the value of the stored data and false if it has expired or
+// is malformed.
+func decode(data []byte, now time.Time) ([]byte, bool) {
+	if len(data) < 8 {
+		return nil, false
+	}
+
+	expires := int64(binary.BigEndian.Uint64(data))
+	if expires != 0 && now.UnixNano() >= expires {
+		return nil, false
+	}
+
+	return data[8:], true
+}
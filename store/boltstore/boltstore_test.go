@@ -0,0 +1,62 @@
+package boltstore_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/store"
+	"github.com/influx6/faux/store/boltstore"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boltstore")
+	if err != nil {
+		t.Fatalf("Should have created temporary directory: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := boltstore.Open(filepath.Join(dir, "store.db"), "entries")
+	if err != nil {
+		t.Fatalf("Should have opened store: %+v", err)
+	}
+	defer s.Close()
+
+	s.Set("user:1", []byte("bob"), 0)
+	s.Set("user:2", []byte("alice"), 0)
+	s.Set("user:3", []byte("eve"), 10*time.Millisecond)
+
+	value, err := s.Get("user:1")
+	if err != nil || string(value) != "bob" {
+		t.Fatalf("Should have retrieved stored value: %q %+v", value, err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := s.Get("user:3"); err != store.ErrNotFound {
+		t.Fatalf("Should have expired entry: %+v", err)
+	}
+
+	var keys []string
+	if err := s.Iterate("user:", func(key string, value []byte) error {
+		keys = append(keys, key)
+		return nil
+	}); err != nil {
+		t.Fatalf("Should have iterated entries: %+v", err)
+	}
+
+	if len(keys) != 2 || keys[0] != "user:1" || keys[1] != "user:2" {
+		t.Fatalf("Should have iterated unexpired keys in order: %+v", keys)
+	}
+
+	if total, err := s.Expire(); err != nil || total != 1 {
+		t.Fatalf("Should have removed expired entry: %d %+v", total, err)
+	}
+
+	s.Delete("user:1")
+	if _, err := s.Get("user:1"); err != store.ErrNotFound {
+		t.Fatalf("Should have deleted entry: %+v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package store
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// entry holds a stored value and it's expiration time, where a zero time
+// never expires.
+type entry struct {
+	value   []byte
+	expires time.Time
+}
+
+func (e entry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// Memory implements the Store interface using an in-memory map. Expired
+// entries are removed when accessed through Get, and all at once by Expire.
+type Memory struct {
+	ml      sync.RWMutex
+	closed  bool
+	entries map[string]entry
+}
+
+// NewMemory returns a new instance of a Memory store.
+func NewMemory() *Memory {
+	return &Memory{entries: make(map[string]entry)}
+}
+
+// Get implements the Store interface. An expired entry is removed when
+// found.
+func (m *Memory) Get(key string) ([]byte, error) {
+	m.ml.RLock()
+	if m.closed {
+		m.ml.RUnlock()
+		return nil, ErrClosed
+	}
+
+	now := time.Now()
+	item, ok := m.entries[key]
+	m.ml.RUnlock()
+
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	if item.expired(now) {
+		m.ml.Lock()
+		// the entry may have been replaced since the read lock was released.
+		if current, ok := m.entries[key]; ok && current.expired(now) {
+			delete(m.entries, key)
+		}
+		m.ml.Unlock()
+
+		return nil, ErrNotFound
+	}
+
+	return copyBytes(item.value), nil
+}
+
+// Set implements the Store interface.
+func (m *Memory) Set(key string, value []byte, ttl time.Duration) error {
+	m.ml.Lock()
+	defer m.ml.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+
+	item := entry{value: copyBytes(value)}
+	if ttl > 0 {
+		item.expires = time.Now().Add(ttl)
+	}
+
+	m.entries[key] = item
+	return nil
+}
+
+// Delete implements the Store interface.
+func (m *Memory) Delete(key string) error {
+	m.ml.Lock()
+	defer m.ml.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+
+	delete(m.entries, key)
+	return nil
+}
+
+// Iterate implements the Store interface. Entries are visited in key order
+// from a snapshot, so fn may modify the store.
+func (m *Memory) Iterate(prefix string, fn IterateFunc) error {
+	m.ml.RLock()
+	if m.closed {
+		m.ml.RUnlock()
+		return ErrClosed
+	}
+
+	now := time.Now()
+	keys := make([]string, 0, len(m.entries))
+	values := make(map[string][]byte)
+	for key, item := range m.entries {
+		if strings.HasPrefix(key, prefix) && !item.expired(now) {
+			keys = append(keys, key)
+			values[key] = item.value
+		}
+	}
+	m.ml.RUnlock()
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := fn(key, copyBytes(values[key])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Expire removes all expired entries, returning the total removed.
+func (m *Memory) Expire() int {
+	m.ml.Lock()
+	defer m.ml.Unlock()
+
+	var total int
+	now := time.Now()
+	for key, item := range m.entries {
+		if item.expired(now) {
+			delete(m.entries, key)
+			total++
+		}
+	}
+
+	return total
+}
+
+// Close implements the Store interface, dropping all entries.
+func (m *Memory) Close() error {
+	m.ml.Lock()
+	defer m.ml.Unlock()
+
+	m.closed = true
+	m.entries = nil
+	return nil
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
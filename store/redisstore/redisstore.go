@@ -0,0 +1,193 @@
+// Package redisstore implements the store.Store interface on top of a Redis
+// connection. It does not depend on a specific client, any connection with a
+// Do method matching Conn works, i.e a redigo redis.Conn.
+package redisstore
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influx6/faux/store"
+)
+
+// errors.
+var (
+	ErrUnexpectedReply = errors.New("unexpected reply from redis")
+)
+
+// scanCount sets the COUNT hint used when scanning keys.
+const scanCount = 100
+
+// Conn defines the connection methods used by Store.
+type Conn interface {
+	Do(commandName string, args ...interface{}) (reply interface{}, err error)
+	Close() error
+}
+
+// Store implements the store.Store interface using Redis, where all keys are
+// prefixed with a namespace. Commands are serialized over the single
+// connection.
+type Store struct {
+	ml        sync.Mutex
+	conn      Conn
+	namespace string
+}
+
+// New returns a new instance of a Store using the giving connection, with all
+// keys stored under the namespace, i.e "app:".
+func New(conn Conn, namespace string) *Store {
+	return &Store{conn: conn, namespace: namespace}
+}
+
+// Get implements the store.Store interface.
+func (s *Store) Get(key string) ([]byte, error) {
+	reply, err := s.do("GET", s.namespace+key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := reply.(type) {
+	case nil:
+		return nil, store.ErrNotFound
+	case []byte:
+		return value, nil
+	case string:
+		return []byte(value), nil
+	}
+
+	return nil, fmt.Errorf("%w: %T", ErrUnexpectedReply, reply)
+}
+
+// Set implements the store.Store interface. A ttl below a millisecond is
+// rounded up to one millisecond.
+func (s *Store) Set(key string, value []byte, ttl time.Duration) error {
+	args := []interface{}{s.namespace + key, value}
+	if ttl > 0 {
+		ms := int64(ttl / time.Millisecond)
+		if ms == 0 {
+			ms = 1
+		}
+		args = append(args, "PX", ms)
+	}
+
+	_, err := s.do("SET", args...)
+	return err
+}
+
+// Delete implements the store.Store interface.
+func (s *Store) Delete(key string) error {
+	_, err := s.do("DEL", s.namespace+key)
+	return err
+}
+
+// Iterate implements the store.Store interface. Keys are visited in the
+// order returned by SCAN, which is unordered, and keys modified during
+// iteration may be visited more than once or not at all.
+func (s *Store) Iterate(prefix string, fn store.IterateFunc) error {
+	match := escape(s.namespace+prefix) + "*"
+	cursor := "0"
+
+	for {
+		reply, err := s.do("SCAN", cursor, "MATCH", match, "COUNT", scanCount)
+		if err != nil {
+			return err
+		}
+
+		next, keys, err := parseScan(reply)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			name := strings.TrimPrefix(key, s.namespace)
+
+			value, err := s.Get(name)
+			if err == store.ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := fn(name, value); err != nil {
+				return err
+			}
+		}
+
+		if next == "0" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// Close implements the store.Store interface, closing the connection.
+func (s *Store) Close() error {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+	return s.conn.Close()
+}
+
+func (s *Store) do(cmd string, args ...interface{}) (interface{}, error) {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+	return s.conn.Do(cmd, args...)
+}
+
+// parseScan returns the next cursor and keys of a SCAN reply.
+func parseScan(reply interface{}) (string, []string, error) {
+	parts, ok := reply.([]interface{})
+	if !ok || len(parts) != 2 {
+		return "", nil, fmt.Errorf("%w: %T", ErrUnexpectedReply, reply)
+	}
+
+	cursor, ok := toString(parts[0])
+	if !ok {
+		return "", nil, fmt.Errorf("%w: cursor %T", ErrUnexpectedReply, parts[0])
+	}
+
+	items, ok := parts[1].([]interface{})
+	if !ok {
+		return "", nil, fmt.Errorf("%w: keys %T", ErrUnexpectedReply, parts[1])
+	}
+
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		key, ok := toString(item)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: key %T", ErrUnexpectedReply, item)
+		}
+		keys = append(keys, key)
+	}
+
+	return cursor, keys, nil
+}
+
+func toString(v interface{}) (string, bool) {
+	switch value := v.(type) {
+	case []byte:
+		return string(value), true
+	case string:
+		return value, true
+	case int64:
+		return strconv.FormatInt(value, 10), true
+	}
+	return "", false
+}
+
+// escape quotes the glob characters of a SCAN MATCH pattern.
+func escape(pattern string) string {
+	var b strings.Builder
+	for _, r := range pattern {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,126 @@
+package redisstore_test
+
+import (
+	"errors"
+	"path"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/store"
+	"github.com/influx6/faux/store/redisstore"
+)
+
+// fakeConn implements a tiny subset of redis commands in memory, replying
+// with the same types as redigo.
+type fakeConn struct {
+	data map[string][]byte
+	ttl  map[string]int64
+}
+
+func (f *fakeConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	switch cmd {
+	case "GET":
+		value, ok := f.data[args[0].(string)]
+		if !ok {
+			return nil, nil
+		}
+		return value, nil
+	case "SET":
+		key := args[0].(string)
+		f.data[key] = args[1].([]byte)
+		if len(args) == 4 {
+			f.ttl[key] = args[3].(int64)
+		}
+		return "OK", nil
+	case "DEL":
+		delete(f.data, args[0].(string))
+		return int64(1), nil
+	case "SCAN":
+		var keys []string
+		for key := range f.data {
+			if ok, _ := path.Match(args[2].(string), key); ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		// return keys in pages of one to exercise the cursor.
+		cursor, _ := strconv.Atoi(args[0].(string))
+		if cursor >= len(keys) {
+			return []interface{}{[]byte("0"), []interface{}{}}, nil
+		}
+
+		next := strconv.Itoa(cursor + 1)
+		if cursor+1 >= len(keys) {
+			next = "0"
+		}
+		return []interface{}{[]byte(next), []interface{}{[]byte(keys[cursor])}}, nil
+	}
+
+	return nil, errors.New("unknown command " + cmd)
+}
+
+func (f *fakeConn) Close() error { return nil }
+
+func TestStore(t *testing.T) {
+	conn := &fakeConn{data: make(map[string][]byte), ttl: make(map[string]int64)}
+	s := redisstore.New(conn, "app:")
+
+	s.Set("user:1", []byte("bob"), 0)
+	s.Set("user:2", []byte("alice"), 2*time.Second)
+	s.Set("session:1", []byte("token"), 0)
+
+	if _, ok := conn.data["app:user:1"]; !ok {
+		t.Fatalf("Should have stored key under namespace: %+v", conn.data)
+	}
+
+	if conn.ttl["app:user:2"] != 2000 {
+		t.Fatalf("Should have set expiry in milliseconds: %d", conn.ttl["app:user:2"])
+	}
+
+	value, err := s.Get("user:1")
+	if err != nil || string(value) != "bob" {
+		t.Fatalf("Should have retrieved stored value: %q %+v", value, err)
+	}
+
+	var keys []string
+	if err := s.Iterate("user:", func(key string, value []byte) error {
+		keys = append(keys, key)
+		return nil
+	}); err != nil {
+		t.Fatalf("Should have iterated entries: %+v", err)
+	}
+
+	if len(keys) != 2 || keys[0] != "user:1" || keys[1] != "user:2" {
+		t.Fatalf("Should have iterated prefixed keys without namespace: %+v", keys)
+	}
+
+	s.Delete("user:1")
+	if _, err := s.Get("user:1"); err != store.ErrNotFound {
+		t.Fatalf("Should have deleted entry: %+v", err)
+	}
+}
+
+// replyConn answers every command with the same reply.
+type replyConn struct {
+	reply interface{}
+}
+
+func (r replyConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	return r.reply, nil
+}
+
+func (r replyConn) Close() error { return nil }
+
+func TestStoreUnexpectedReply(t *testing.T) {
+	if _, err := redisstore.New(replyConn{reply: int64(1)}, "app:").Get("user:1"); !errors.Is(err, redisstore.ErrUnexpectedReply) {
+		t.Fatalf("Should have rejected unexpected get reply: %+v", err)
+	}
+
+	s := redisstore.New(replyConn{reply: []interface{}{[]byte("0"), []interface{}{int64(1)}}}, "app:")
+	if err := s.Iterate("", func(string, []byte) error { return nil }); !errors.Is(err, redisstore.ErrUnexpectedReply) {
+		t.Fatalf("Should have rejected unexpected scan reply: %+v", err)
+	}
+}
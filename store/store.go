@@ -0,0 +1,115 @@
+// Package store defines a key/value Store interface with expiring entries,
+// an in-memory implementation and an instrumenting wrapper. Durable
+// backends live in sub-packages, i.e store/boltstore and store/redisstore.
+package store
+
+import (
+	"errors"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+)
+
+// errors.
+var (
+	ErrNotFound = errors.New("key not found")
+	ErrClosed   = errors.New("store is closed")
+)
+
+// IterateFunc defines a function type called for every entry during
+// iteration. Returning an error stops the iteration and is returned by
+// Iterate.
+type IterateFunc func(key string, value []byte) error
+
+// Store defines an interface for key/value storage where entries may expire.
+type Store interface {
+	// Get returns the value of the key or ErrNotFound if it does not exist or
+	// has expired.
+	Get(key string) ([]byte, error)
+
+	// Set stores the value under the key, where a ttl of zero never expires.
+	Set(key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the key, returning no error if it does not exist.
+	Delete(key string) error
+
+	// Iterate calls fn for all unexpired entries whose key starts with the
+	// prefix, in key order where the backend supports it.
+	Iterate(prefix string, fn IterateFunc) error
+
+	// Close releases the resources of the store.
+	Close() error
+}
+
+//==============================================================================
+
+// instrumented wraps a Store, emitting the duration and outcome of every
+// operation.
+type instrumented struct {
+	name  string
+	store Store
+	m     metrics.Metrics
+}
+
+// Instrument returns a Store which emits a metrics entry with the id
+// "store:<op>" for every operation on the provided store, carrying the store
+// name, key, duration and any error.
+func Instrument(name string, s Store, m metrics.Metrics) Store {
+	return instrumented{name: name, store: s, m: m}
+}
+
+// Get implements the Store interface.
+func (i instrumented) Get(key string) ([]byte, error) {
+	start := time.Now()
+	value, err := i.store.Get(key)
+	i.emit("get", key, start, err)
+	return value, err
+}
+
+// Set implements the Store interface.
+func (i instrumented) Set(key string, value []byte, ttl time.Duration) error {
+	start := time.Now()
+	err := i.store.Set(key, value, ttl)
+	i.emit("set", key, start, err)
+	return err
+}
+
+// Delete implements the Store interface.
+func (i instrumented) Delete(key string) error {
+	start := time.Now()
+	err := i.store.Delete(key)
+	i.emit("delete", key, start, err)
+	return err
+}
+
+// Iterate implements the Store interface.
+func (i instrumented) Iterate(prefix string, fn IterateFunc) error {
+	start := time.Now()
+	err := i.store.Iterate(prefix, fn)
+	i.emit("iterate", prefix, start, err)
+	return err
+}
+
+// Close implements the Store interface.
+func (i instrumented) Close() error {
+	start := time.Now()
+	err := i.store.Close()
+	i.emit("close", "", start, err)
+	return err
+}
+
+func (i instrumented) emit(op string, key string, start time.Time, err error) {
+	fields := metrics.Field{
+		"store":    i.name,
+		"key":      key,
+		"duration": time.Since(start),
+		"found":    err != ErrNotFound,
+	}
+
+	if err != nil && err != ErrNotFound {
+		i.m.Emit(metrics.Error(err), metrics.WithID("store:"+op), metrics.WithFields(fields))
+		return
+	}
+
+	i.m.Emit(metrics.Info("Store operation"), metrics.WithID("store:"+op), metrics.WithFields(fields))
+}
@@ -0,0 +1,93 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+	"github.com/influx6/faux/metrics/memory"
+	"github.com/influx6/faux/store"
+)
+
+func TestMemory(t *testing.T) {
+	s := store.NewMemory()
+
+	if err := s.Set("user:1", []byte("bob"), 0); err != nil {
+		t.Fatalf("Should have stored value: %+v", err)
+	}
+
+	s.Set("user:2", []byte("alice"), 0)
+	s.Set("session:1", []byte("token"), 10*time.Millisecond)
+	s.Set("session:2", []byte("token"), 10*time.Millisecond)
+
+	value, err := s.Get("user:1")
+	if err != nil || string(value) != "bob" {
+		t.Fatalf("Should have retrieved stored value: %q %+v", value, err)
+	}
+
+	var keys []string
+	if err := s.Iterate("user:", func(key string, value []byte) error {
+		keys = append(keys, key)
+		return nil
+	}); err != nil {
+		t.Fatalf("Should have iterated entries: %+v", err)
+	}
+
+	if len(keys) != 2 || keys[0] != "user:1" || keys[1] != "user:2" {
+		t.Fatalf("Should have iterated prefixed keys in order: %+v", keys)
+	}
+
+	stop := errors.New("stop")
+	if err := s.Iterate("", func(key string, value []byte) error {
+		return stop
+	}); err != stop {
+		t.Fatalf("Should have returned iteration error: %+v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := s.Get("session:1"); err != store.ErrNotFound {
+		t.Fatalf("Should have expired entry: %+v", err)
+	}
+
+	// session:1 was removed by Get, leaving only session:2 for Expire.
+	if total := s.Expire(); total != 1 {
+		t.Fatalf("Should have removed only the expired entry not yet accessed: %d", total)
+	}
+
+	if total := s.Expire(); total != 0 {
+		t.Fatalf("Should have no expired entries left: %d", total)
+	}
+
+	s.Delete("user:1")
+	if _, err := s.Get("user:1"); err != store.ErrNotFound {
+		t.Fatalf("Should have deleted entry: %+v", err)
+	}
+
+	s.Close()
+	if err := s.Set("user:1", nil, 0); err != store.ErrClosed {
+		t.Fatalf("Should have rejected use after close: %+v", err)
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	var events memory.Memory
+	s := store.Instrument("users", store.NewMemory(), metrics.New(&events))
+
+	s.Set("user:1", []byte("bob"), 0)
+	s.Get("user:1")
+	s.Get("user:2")
+
+	if len(events.Data) != 3 {
+		t.Fatalf("Should have emitted entry per operation: %d", len(events.Data))
+	}
+
+	if events.Data[0].ID != "store:set" || events.Data[1].ID != "store:get" {
+		t.Fatalf("Should have emitted operation ids: %q %q", events.Data[0].ID, events.Data[1].ID)
+	}
+
+	if found, _ := events.Data[2].Field.GetBool("found"); found {
+		t.Fatalf("Should have marked missing key as not found")
+	}
+}
@@ -0,0 +1,114 @@
+package streamio
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// limiter implements a token bucket over bytes, refilled at rate bytes per
+// second and holding at most rate bytes.
+type limiter struct {
+	ml     sync.Mutex
+	rate   int
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rate int) *limiter {
+	return &limiter{rate: rate, tokens: float64(rate), last: time.Now()}
+}
+
+// wait blocks until n bytes are available from the bucket, which must not
+// exceed the rate.
+func (l *limiter) wait(n int) {
+	l.ml.Lock()
+	defer l.ml.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * float64(l.rate)
+	if l.tokens > float64(l.rate) {
+		l.tokens = float64(l.rate)
+	}
+	l.last = now
+
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return
+	}
+
+	// sleep for the debt while holding the lock so concurrent callers queue
+	// behind us.
+	delay := time.Duration(-l.tokens / float64(l.rate) * float64(time.Second))
+	time.Sleep(delay)
+	l.tokens = 0
+	l.last = time.Now()
+}
+
+//==============================================================================
+
+// limitedReader implements io.Reader, bounding reads to a rate.
+type limitedReader struct {
+	r io.Reader
+	l *limiter
+}
+
+// LimitReader returns a io.Reader which reads from r at no more than
+// bytesPerSec bytes per second. A non-positive rate returns r unchanged.
+func LimitReader(r io.Reader, bytesPerSec int) io.Reader {
+	if bytesPerSec <= 0 {
+		return r
+	}
+	return &limitedReader{r: r, l: newLimiter(bytesPerSec)}
+}
+
+// Read implements the io.Reader interface.
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if len(p) > lr.l.rate {
+		p = p[:lr.l.rate]
+	}
+
+	n, err := lr.r.Read(p)
+	if n > 0 {
+		lr.l.wait(n)
+	}
+	return n, err
+}
+
+// limitedWriter implements io.Writer, bounding writes to a rate.
+type limitedWriter struct {
+	w io.Writer
+	l *limiter
+}
+
+// LimitWriter returns a io.Writer which writes into w at no more than
+// bytesPerSec bytes per second, splitting large writes as needed. A
+// non-positive rate returns w unchanged.
+func LimitWriter(w io.Writer, bytesPerSec int) io.Writer {
+	if bytesPerSec <= 0 {
+		return w
+	}
+	return &limitedWriter{w: w, l: newLimiter(bytesPerSec)}
+}
+
+// Write implements the io.Writer interface.
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > lw.l.rate {
+			chunk = chunk[:lw.l.rate]
+		}
+
+		lw.l.wait(len(chunk))
+
+		n, err := lw.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+
+		p = p[n:]
+	}
+	return written, nil
+}
@@ -0,0 +1,180 @@
+// Package streamio provides io composition helpers for building observable
+// data-transfer pipelines: readers and writers which report transferred
+// bytes and throughput into metrics, rate-limited readers and writers, and
+// line and frame splitters which deliver chunks to a callback.
+package streamio
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+)
+
+// Meter counts bytes transferred through a stream since it's creation.
+type Meter struct {
+	bytes int64
+	start time.Time
+}
+
+// NewMeter returns a new instance of a Meter starting from now.
+func NewMeter() *Meter {
+	return &Meter{start: time.Now()}
+}
+
+// Add records n bytes as transferred.
+func (m *Meter) Add(n int) {
+	atomic.AddInt64(&m.bytes, int64(n))
+}
+
+// Bytes returns the total of bytes transferred.
+func (m *Meter) Bytes() int64 {
+	return atomic.LoadInt64(&m.bytes)
+}
+
+// Elapsed returns the time passed since the meter was created.
+func (m *Meter) Elapsed() time.Duration {
+	return time.Since(m.start)
+}
+
+// Throughput returns the average bytes transferred per second.
+func (m *Meter) Throughput() float64 {
+	elapsed := m.Elapsed().Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(m.Bytes()) / elapsed
+}
+
+// Fields returns the state of the meter as metrics fields.
+func (m *Meter) Fields() metrics.Field {
+	return metrics.Field{
+		"bytes":      m.Bytes(),
+		"duration":   m.Elapsed(),
+		"throughput": m.Throughput(),
+	}
+}
+
+//==============================================================================
+
+// Reader wraps a io.Reader, counting the bytes read and emitting a metrics
+// entry with the id "streamio:read" once the reader returns an error or
+// io.EOF, or is closed.
+type Reader struct {
+	*Meter
+	name string
+	r    io.Reader
+	m    metrics.Metrics
+	once sync.Once
+}
+
+// NewReader returns a new instance of a Reader for r.
+func NewReader(name string, r io.Reader, m metrics.Metrics) *Reader {
+	return &Reader{Meter: NewMeter(), name: name, r: r, m: m}
+}
+
+// TeeReader returns a Reader which writes everything read from r into w,
+// as io.TeeReader does.
+func TeeReader(name string, r io.Reader, w io.Writer, m metrics.Metrics) *Reader {
+	return NewReader(name, io.TeeReader(r, w), m)
+}
+
+// Read implements the io.Reader interface.
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.Add(n)
+
+	if err != nil {
+		r.report(err)
+	}
+
+	return n, err
+}
+
+// Close emits the read entry if it has not been emitted, and closes the
+// underline reader if it implements io.Closer.
+func (r *Reader) Close() error {
+	r.report(nil)
+
+	if closer, ok := r.r.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (r *Reader) report(err error) {
+	r.once.Do(func() {
+		emit(r.m, "streamio:read", r.name, r.Meter, err)
+	})
+}
+
+//==============================================================================
+
+// Writer wraps a io.Writer, counting the bytes written and emitting a
+// metrics entry with the id "streamio:write" once a write fails or the
+// writer is closed.
+type Writer struct {
+	*Meter
+	name string
+	w    io.Writer
+	m    metrics.Metrics
+	once sync.Once
+}
+
+// NewWriter returns a new instance of a Writer for w.
+func NewWriter(name string, w io.Writer, m metrics.Metrics) *Writer {
+	return &Writer{Meter: NewMeter(), name: name, w: w, m: m}
+}
+
+// MultiWriter returns a Writer which duplicates it's writes to all provided
+// writers, as io.MultiWriter does.
+func MultiWriter(name string, m metrics.Metrics, writers ...io.Writer) *Writer {
+	return NewWriter(name, io.MultiWriter(writers...), m)
+}
+
+// Write implements the io.Writer interface.
+func (w *Writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.Add(n)
+
+	if err != nil {
+		w.report(err)
+	}
+
+	return n, err
+}
+
+// Close emits the write entry if it has not been emitted, and closes the
+// underline writer if it implements io.Closer.
+func (w *Writer) Close() error {
+	w.report(nil)
+
+	if closer, ok := w.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (w *Writer) report(err error) {
+	w.once.Do(func() {
+		emit(w.m, "streamio:write", w.name, w.Meter, err)
+	})
+}
+
+func emit(m metrics.Metrics, id string, name string, meter *Meter, err error) {
+	if m == nil {
+		return
+	}
+
+	fields := meter.Fields()
+	fields["stream"] = name
+
+	if err != nil && err != io.EOF {
+		m.Emit(metrics.Error(err), metrics.WithID(id), metrics.WithFields(fields))
+		return
+	}
+
+	m.Emit(metrics.Info("Stream completed"), metrics.WithID(id), metrics.WithFields(fields))
+}
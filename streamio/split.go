@@ -0,0 +1,129 @@
+package streamio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// errors.
+var (
+	ErrFrameTooLarge = errors.New("frame exceeds maximum size")
+)
+
+// ChunkFunc defines a function type which receives a line or frame split
+// from a stream. The slice is only valid until the function returns.
+type ChunkFunc func([]byte) error
+
+// SplitLines reads r until io.EOF, delivering every line without it's line
+// ending to fn. It stops at the first error returned by fn.
+func SplitLines(r io.Reader, fn ChunkFunc) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if err := fn(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// SplitFrames reads r until io.EOF, delivering every frame written by
+// WriteFrame to fn. Frames larger than maxSize return ErrFrameTooLarge, a
+// non-positive maxSize allows any size.
+func SplitFrames(r io.Reader, maxSize int, fn ChunkFunc) error {
+	var header [4]byte
+	var frame []byte
+
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		size := int(binary.BigEndian.Uint32(header[:]))
+		if maxSize > 0 && size > maxSize {
+			return ErrFrameTooLarge
+		}
+
+		if cap(frame) < size {
+			frame = make([]byte, size)
+		}
+		frame = frame[:size]
+
+		if _, err := io.ReadFull(r, frame); err != nil {
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+
+		if err := fn(frame); err != nil {
+			return err
+		}
+	}
+}
+
+// WriteFrame writes p into w prefixed by it's length as a 4 byte big endian
+// integer, to be read back by SplitFrames.
+func WriteFrame(w io.Writer, p []byte) error {
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(p)))
+
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+
+	_, err := w.Write(p)
+	return err
+}
+
+//==============================================================================
+
+// LineWriter implements io.WriteCloser, delivering every complete line
+// written to it to a ChunkFunc, which allows it to sit at the end of a
+// pipeline such as exec output or io.Copy.
+type LineWriter struct {
+	fn  ChunkFunc
+	buf bytes.Buffer
+}
+
+// NewLineWriter returns a new instance of a LineWriter.
+func NewLineWriter(fn ChunkFunc) *LineWriter {
+	return &LineWriter{fn: fn}
+}
+
+// Write implements the io.Writer interface.
+func (lw *LineWriter) Write(p []byte) (int, error) {
+	lw.buf.Write(p)
+
+	for {
+		data := lw.buf.Bytes()
+		index := bytes.IndexByte(data, '\n')
+		if index == -1 {
+			return len(p), nil
+		}
+
+		line := bytes.TrimSuffix(data[:index], []byte("\r"))
+		err := lw.fn(line)
+		lw.buf.Next(index + 1)
+
+		if err != nil {
+			return len(p), err
+		}
+	}
+}
+
+// Close delivers any remaining partial line.
+func (lw *LineWriter) Close() error {
+	if lw.buf.Len() == 0 {
+		return nil
+	}
+
+	err := lw.fn(lw.buf.Bytes())
+	lw.buf.Reset()
+	return err
+}
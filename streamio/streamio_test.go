@@ -0,0 +1,141 @@
+package streamio_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+	"github.com/influx6/faux/streamio"
+	"github.com/influx6/faux/tester"
+)
+
+func TestTeeReader(t *testing.T) {
+	var rec tester.Recorder
+	var copied bytes.Buffer
+
+	reader := streamio.TeeReader("upload", strings.NewReader("hello world"), &copied, metrics.New(&rec))
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Should have read all data: %+v", err)
+	}
+
+	if string(data) != "hello world" || copied.String() != "hello world" {
+		t.Fatalf("Should have copied data: %q %q", data, copied.String())
+	}
+
+	if reader.Bytes() != 11 {
+		t.Fatalf("Should have counted 11 bytes: %d", reader.Bytes())
+	}
+
+	reader.Close()
+
+	entries := rec.Find("streamio:read")
+	if len(entries) != 1 {
+		t.Fatalf("Should have emitted a single entry: %+v", entries)
+	}
+
+	if entries[0].Field["bytes"] != int64(11) || entries[0].Field["stream"] != "upload" {
+		t.Fatalf("Should have reported bytes and stream: %+v", entries[0].Field)
+	}
+}
+
+func TestMultiWriter(t *testing.T) {
+	var rec tester.Recorder
+	var first, second bytes.Buffer
+
+	writer := streamio.MultiWriter("fanout", metrics.New(&rec), &first, &second)
+	io.WriteString(writer, "data")
+	writer.Close()
+
+	if first.String() != "data" || second.String() != "data" {
+		t.Fatalf("Should have written to all writers: %q %q", first.String(), second.String())
+	}
+
+	if entries := rec.Find("streamio:write"); len(entries) != 1 || entries[0].Field["bytes"] != int64(4) {
+		t.Fatalf("Should have emitted write entry: %+v", entries)
+	}
+}
+
+func TestLimitWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer := streamio.LimitWriter(&out, 100)
+
+	start := time.Now()
+	writer.Write(make([]byte, 150))
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("Should have throttled write beyond burst: %s", elapsed)
+	}
+
+	if out.Len() != 150 {
+		t.Fatalf("Should have written all bytes: %d", out.Len())
+	}
+}
+
+func TestLimitReader(t *testing.T) {
+	reader := streamio.LimitReader(bytes.NewReader(make([]byte, 150)), 100)
+
+	start := time.Now()
+	data, err := ioutil.ReadAll(reader)
+	if err != nil || len(data) != 150 {
+		t.Fatalf("Should have read all bytes: %d %+v", len(data), err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("Should have throttled read beyond burst: %s", elapsed)
+	}
+}
+
+func TestSplitLines(t *testing.T) {
+	var lines []string
+	err := streamio.SplitLines(strings.NewReader("a\nb\r\nc"), func(line []byte) error {
+		lines = append(lines, string(line))
+		return nil
+	})
+
+	if err != nil || strings.Join(lines, ",") != "a,b,c" {
+		t.Fatalf("Should have split lines: %q %+v", lines, err)
+	}
+}
+
+func TestFrames(t *testing.T) {
+	var buf bytes.Buffer
+	streamio.WriteFrame(&buf, []byte("first"))
+	streamio.WriteFrame(&buf, []byte(""))
+	streamio.WriteFrame(&buf, []byte("third"))
+
+	var frames []string
+	err := streamio.SplitFrames(bytes.NewReader(buf.Bytes()), 0, func(frame []byte) error {
+		frames = append(frames, string(frame))
+		return nil
+	})
+
+	if err != nil || strings.Join(frames, ",") != "first,,third" {
+		t.Fatalf("Should have split frames: %q %+v", frames, err)
+	}
+
+	err = streamio.SplitFrames(bytes.NewReader(buf.Bytes()), 3, func([]byte) error { return nil })
+	if err != streamio.ErrFrameTooLarge {
+		t.Fatalf("Should have rejected large frame: %+v", err)
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	writer := streamio.NewLineWriter(func(line []byte) error {
+		lines = append(lines, string(line))
+		return nil
+	})
+
+	io.WriteString(writer, "one\ntw")
+	io.WriteString(writer, "o\nthree")
+	writer.Close()
+
+	if strings.Join(lines, ",") != "one,two,three" {
+		t.Fatalf("Should have delivered lines: %q", lines)
+	}
+}
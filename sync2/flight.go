@@ -0,0 +1,91 @@
+package sync2
+
+import (
+	"fmt"
+	"sync"
+)
+
+// PanicError is returned to callers waiting on a Flight call which panicked,
+// carrying the recovered value. The caller which ran the call panics as
+// usual.
+type PanicError struct {
+	Value interface{}
+}
+
+// Error implements the error interface.
+func (p PanicError) Error() string {
+	return fmt.Sprintf("flight call panicked: %v", p.Value)
+}
+
+// call holds the state of an in-flight call for a giving key.
+type call struct {
+	wg     sync.WaitGroup
+	value  interface{}
+	err    error
+	shared bool
+}
+
+// Flight deduplicates concurrent calls for the same key, so only one runs
+// while the others wait for and share it's result. The zero value is ready
+// for use.
+type Flight struct {
+	ml    sync.Mutex
+	calls map[interface{}]*call
+}
+
+// Do calls fn for the key unless a call for it is already in flight, in
+// which case it waits for that call. It returns the result and whether it
+// was shared with other callers. If fn panics, waiting callers receive a
+// PanicError and the panic is propagated to the caller which ran fn.
+func (f *Flight) Do(key interface{}, fn func() (interface{}, error)) (interface{}, error, bool) {
+	f.ml.Lock()
+	if f.calls == nil {
+		f.calls = make(map[interface{}]*call)
+	}
+
+	if pending, ok := f.calls[key]; ok {
+		pending.shared = true
+		f.ml.Unlock()
+		pending.wg.Wait()
+		return pending.value, pending.err, true
+	}
+
+	pending := new(call)
+	pending.wg.Add(1)
+	f.calls[key] = pending
+	f.ml.Unlock()
+
+	defer func() {
+		recovered := recover()
+		if recovered != nil {
+			pending.value, pending.err = nil, PanicError{Value: recovered}
+		}
+
+		f.ml.Lock()
+		if f.calls[key] == pending {
+			delete(f.calls, key)
+		}
+		f.ml.Unlock()
+		pending.wg.Done()
+
+		if recovered != nil {
+			panic(recovered)
+		}
+	}()
+
+	pending.value, pending.err = fn()
+
+	f.ml.Lock()
+	shared := pending.shared
+	f.ml.Unlock()
+
+	return pending.value, pending.err, shared
+}
+
+// Forget drops the in-flight call for the key, so the next Do starts a new
+// call instead of waiting on it.
+func (f *Flight) Forget(key interface{}) {
+	f.ml.Lock()
+	defer f.ml.Unlock()
+	delete(f.calls, key)
+}
@@ -0,0 +1,135 @@
+package sync2
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influx6/faux/panics"
+)
+
+// Group runs functions on their own goroutines, bounded to a limit of
+// concurrently running ones, and collects the first error. Panics raised
+// by functions are returned as errors.
+type Group struct {
+	wg     sync.WaitGroup
+	sem    chan struct{}
+	cancel context.CancelFunc
+	once   sync.Once
+	err    error
+}
+
+// NewGroup returns a new instance of a Group running at most limit functions
+// at once, where a limit of zero or less is unbounded.
+func NewGroup(limit int) *Group {
+	g := &Group{}
+	if limit > 0 {
+		g.sem = make(chan struct{}, limit)
+	}
+	return g
+}
+
+// GroupWithContext returns a new Group and a context derived from ctx which
+// is cancelled when the first function fails or Wait returns.
+func GroupWithContext(ctx context.Context, limit int) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	g := NewGroup(limit)
+	g.cancel = cancel
+	return g, ctx
+}
+
+// Go runs fn on a new goroutine, blocking while the group is at it's limit.
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		if err := panics.Guard(fn); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				if g.cancel != nil {
+					g.cancel()
+				}
+			})
+		}
+	}()
+}
+
+// Wait blocks until all functions have returned, returning the first error.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return g.err
+}
+
+//==============================================================================
+
+// Latch defines a countdown latch which releases all waiters once counted
+// down to zero.
+type Latch struct {
+	ml    sync.Mutex
+	count int
+	done  chan struct{}
+}
+
+// NewLatch returns a new instance of a Latch with the giving count. A count
+// of zero or less is released immediately.
+func NewLatch(count int) *Latch {
+	l := &Latch{count: count, done: make(chan struct{})}
+	if count <= 0 {
+		close(l.done)
+	}
+	return l
+}
+
+// CountDown decrements the count, releasing the latch when it reaches zero.
+// Calls after release have no effect.
+func (l *Latch) CountDown() {
+	l.ml.Lock()
+	defer l.ml.Unlock()
+
+	if l.count <= 0 {
+		return
+	}
+
+	l.count--
+	if l.count == 0 {
+		close(l.done)
+	}
+}
+
+// Count returns the remaining count.
+func (l *Latch) Count() int {
+	l.ml.Lock()
+	defer l.ml.Unlock()
+	return l.count
+}
+
+// Done returns a channel which is closed once the latch is released.
+func (l *Latch) Done() <-chan struct{} {
+	return l.done
+}
+
+// Wait blocks until the latch is released.
+func (l *Latch) Wait() {
+	<-l.done
+}
+
+// WaitContext blocks until the latch is released or the context is done,
+// returning the context error in the latter case.
+func (l *Latch) WaitContext(ctx context.Context) error {
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
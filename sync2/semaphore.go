@@ -0,0 +1,134 @@
+// Package sync2 provides concurrency primitives beyond the sync package:
+// weighted semaphores, bounded error groups, countdown latches and
+// single-flight call deduplication.
+package sync2
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+)
+
+// errors.
+var (
+	ErrWeightTooLarge = errors.New("weight exceeds semaphore size")
+)
+
+// waiter holds a pending Acquire call.
+type waiter struct {
+	weight int64
+	ready  chan struct{}
+}
+
+// Semaphore defines a weighted semaphore, where waiters are served in the
+// order they arrived so large requests are not starved by small ones.
+type Semaphore struct {
+	ml      sync.Mutex
+	size    int64
+	current int64
+	waiters list.List
+}
+
+// NewSemaphore returns a new instance of a Semaphore with the giving total
+// weight.
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{size: size}
+}
+
+// Acquire blocks until the weight is available or the context is done,
+// returning the context error in the latter case.
+func (s *Semaphore) Acquire(ctx context.Context, weight int64) error {
+	s.ml.Lock()
+	if weight > s.size {
+		s.ml.Unlock()
+		return ErrWeightTooLarge
+	}
+
+	if s.size-s.current >= weight && s.waiters.Len() == 0 {
+		s.current += weight
+		s.ml.Unlock()
+		return nil
+	}
+
+	w := waiter{weight: weight, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.ml.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.ml.Lock()
+		defer s.ml.Unlock()
+
+		select {
+		case <-w.ready:
+			// acquired after the context was done, give it back.
+			s.current -= weight
+			s.notify()
+		default:
+			front := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+
+			// the waiter at the front may have blocked smaller ones behind it.
+			if front {
+				s.notify()
+			}
+		}
+
+		return ctx.Err()
+	}
+}
+
+// TryAcquire acquires the weight without blocking, returning false if it is
+// not available.
+func (s *Semaphore) TryAcquire(weight int64) bool {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	if s.size-s.current >= weight && s.waiters.Len() == 0 {
+		s.current += weight
+		return true
+	}
+	return false
+}
+
+// Release returns the weight to the semaphore. It panics if more is released
+// than was acquired.
+func (s *Semaphore) Release(weight int64) {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	s.current -= weight
+	if s.current < 0 {
+		panic("sync2: semaphore released more than held")
+	}
+	s.notify()
+}
+
+// Available returns the weight currently available.
+func (s *Semaphore) Available() int64 {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+	return s.size - s.current
+}
+
+// notify wakes waiters in order while their weight is available.
+func (s *Semaphore) notify() {
+	for {
+		next := s.waiters.Front()
+		if next == nil {
+			return
+		}
+
+		w := next.Value.(waiter)
+		if s.size-s.current < w.weight {
+			return
+		}
+
+		s.current += w.weight
+		s.waiters.Remove(next)
+		close(w.ready)
+	}
+}
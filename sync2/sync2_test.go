@@ -0,0 +1,215 @@
+package sync2_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/sync2"
+)
+
+func TestSemaphore(t *testing.T) {
+	sem := sync2.NewSemaphore(3)
+
+	if err := sem.Acquire(context.Background(), 2); err != nil {
+		t.Fatalf("Should have acquired weight: %+v", err)
+	}
+
+	if sem.TryAcquire(2) {
+		t.Fatalf("Should not have acquired weight beyond size")
+	}
+
+	if err := sem.Acquire(context.Background(), 4); err != sync2.ErrWeightTooLarge {
+		t.Fatalf("Should have rejected weight larger than size: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := sem.Acquire(ctx, 2); err != context.DeadlineExceeded {
+		t.Fatalf("Should have timed out waiting for weight: %+v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		sem.Acquire(context.Background(), 3)
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatalf("Should have waited for weight to be released")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	sem.Release(2)
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatalf("Should have acquired weight after release")
+	}
+
+	if sem.Available() != 0 {
+		t.Fatalf("Should have no weight available: %d", sem.Available())
+	}
+}
+
+func TestGroup(t *testing.T) {
+	var running, peak int32
+
+	g := sync2.NewGroup(2)
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Should have completed without error: %+v", err)
+	}
+
+	if peak > 2 {
+		t.Fatalf("Should have bounded concurrent functions to 2: %d", peak)
+	}
+
+	bad := errors.New("bad")
+	eg, ctx := sync2.GroupWithContext(context.Background(), 0)
+	eg.Go(func() error { return bad })
+	eg.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if err := eg.Wait(); err != bad {
+		t.Fatalf("Should have returned first error: %+v", err)
+	}
+}
+
+func TestLatch(t *testing.T) {
+	latch := sync2.NewLatch(3)
+
+	for i := 0; i < 3; i++ {
+		go latch.CountDown()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := latch.WaitContext(ctx); err != nil {
+		t.Fatalf("Should have released latch: %+v", err)
+	}
+
+	latch.CountDown()
+	if latch.Count() != 0 {
+		t.Fatalf("Should not count below zero: %d", latch.Count())
+	}
+}
+
+func TestFlight(t *testing.T) {
+	var f sync2.Flight
+	var calls int32
+
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	var shared int32
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err, isShared := f.Do("key", func() (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return "value", nil
+			})
+
+			if err != nil || value != "value" {
+				t.Errorf("Should have received shared value: %+v %+v", value, err)
+			}
+
+			if isShared {
+				atomic.AddInt32(&shared, 1)
+			}
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("Should have called function once: %d", calls)
+	}
+
+	if shared != 5 {
+		t.Fatalf("Should have shared result with all callers: %d", shared)
+	}
+}
+
+func TestFlightPanic(t *testing.T) {
+	var f sync2.Flight
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	recovered := make(chan interface{}, 1)
+
+	go func() {
+		defer func() {
+			recovered <- recover()
+		}()
+
+		f.Do("key", func() (interface{}, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+
+	<-started
+
+	result := make(chan error, 1)
+	go func() {
+		value, err, shared := f.Do("key", func() (interface{}, error) {
+			return "other", nil
+		})
+
+		if value != nil || !shared {
+			t.Errorf("Should have shared panicked call: %+v %t", value, shared)
+		}
+		result <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if r := <-recovered; r != "boom" {
+		t.Fatalf("Should have propagated panic to caller: %+v", r)
+	}
+
+	err := <-result
+	if perr, ok := err.(sync2.PanicError); !ok || perr.Value != "boom" {
+		t.Fatalf("Should have returned PanicError to waiting caller: %+v", err)
+	}
+
+	value, err, _ := f.Do("key", func() (interface{}, error) {
+		return "fresh", nil
+	})
+
+	if value != "fresh" || err != nil {
+		t.Fatalf("Should have started new call after panic: %+v %+v", value, err)
+	}
+}
@@ -0,0 +1,217 @@
+// Package tester provides assertion helpers which report in the check and
+// cross mark style of the tests package, failing through testing.TB rather
+// than exiting the process, with readable diffs for mismatched values.
+package tester
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/dump"
+	"github.com/influx6/faux/metrics"
+)
+
+// marks.
+const (
+	succeedMark = "\u2713"
+	failedMark  = "\u2717"
+)
+
+// T wraps a testing.TB with assertion methods. Passing assertions are
+// logged with a check mark and failing ones stop the test with a cross mark.
+type T struct {
+	testing.TB
+}
+
+// New returns a new instance of a T.
+func New(tb testing.TB) *T {
+	return &T{TB: tb}
+}
+
+// Passed logs the passing of a check using the giving message and values.
+func (t *T) Passed(message string, val ...interface{}) {
+	t.Helper()
+	t.Logf("\t%s\t %s", succeedMark, fmt.Sprintf(message, val...))
+}
+
+// Failed fails the test using the giving message and values.
+func (t *T) Failed(message string, val ...interface{}) {
+	t.Helper()
+	t.Fatalf("\t%s\t %s", failedMark, fmt.Sprintf(message, val...))
+}
+
+// Check passes or fails depending on ok.
+func (t *T) Check(ok bool, message string, val ...interface{}) {
+	t.Helper()
+	if !ok {
+		t.Failed(message, val...)
+		return
+	}
+
+	t.Passed(message, val...)
+}
+
+// Equal checks that expected and actual are deeply equal, failing with the
+// differences between them otherwise.
+func (t *T) Equal(expected, actual interface{}, message string, val ...interface{}) {
+	t.Helper()
+
+	if reflect.DeepEqual(expected, actual) {
+		t.Passed(message, val...)
+		return
+	}
+
+	t.Failed("%s\n%s", fmt.Sprintf(message, val...), indent(diff(expected, actual)))
+}
+
+// NotEqual checks that expected and actual are not deeply equal.
+func (t *T) NotEqual(expected, actual interface{}, message string, val ...interface{}) {
+	t.Helper()
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Passed(message, val...)
+		return
+	}
+
+	t.Failed("%s\n\tboth: %s", fmt.Sprintf(message, val...), dump.Compact.Sprint(actual))
+}
+
+// NoError checks that err is nil.
+func (t *T) NoError(err error, message string, val ...interface{}) {
+	t.Helper()
+
+	if err == nil {
+		t.Passed(message, val...)
+		return
+	}
+
+	t.Failed("%s\n\terror: %+v", fmt.Sprintf(message, val...), err)
+}
+
+// ExpectError checks that err is not nil.
+func (t *T) ExpectError(err error, message string, val ...interface{}) {
+	t.Helper()
+
+	if err != nil {
+		t.Passed("%s\n\t-\t Received Expected Error: %+q", fmt.Sprintf(message, val...), err)
+		return
+	}
+
+	t.Failed("%s\n\terror: expected an error, got nil", fmt.Sprintf(message, val...))
+}
+
+// Eventually checks that cond returns true before the timeout expires,
+// calling it every 10ms.
+func (t *T) Eventually(cond func() bool, timeout time.Duration, message string, val ...interface{}) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		if cond() {
+			t.Passed(message, val...)
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Failed("%s\n\terror: condition not met within %s", fmt.Sprintf(message, val...), timeout)
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// PanicsWith checks that fn panics with a value deeply equal to expected.
+// A nil expected accepts any panic value.
+func (t *T) PanicsWith(expected interface{}, fn func(), message string, val ...interface{}) {
+	t.Helper()
+
+	recovered, panicked := catch(fn)
+	switch {
+	case !panicked:
+		t.Failed("%s\n\terror: function did not panic", fmt.Sprintf(message, val...))
+	case expected != nil && !reflect.DeepEqual(expected, recovered):
+		t.Failed("%s\n%s", fmt.Sprintf(message, val...), indent(diff(expected, recovered)))
+	default:
+		t.Passed(message, val...)
+	}
+}
+
+func catch(fn func()) (recovered interface{}, panicked bool) {
+	panicked = true
+	defer func() {
+		if panicked {
+			recovered = recover()
+		}
+	}()
+
+	fn()
+	panicked = false
+	return
+}
+
+// diff returns the readable differences between expected and actual.
+func diff(expected, actual interface{}) string {
+	if diffs := dump.Diff(expected, actual); len(diffs) != 0 {
+		return strings.TrimSuffix(diffs.String(), "\n")
+	}
+
+	return fmt.Sprintf("expected: %s\nactual:   %s", dump.Compact.Sprint(expected), dump.Compact.Sprint(actual))
+}
+
+func indent(s string) string {
+	return "\t" + strings.Replace(s, "\n", "\n\t", -1)
+}
+
+//==============================================================================
+
+// Recorder implements metrics.Processors by recording all entries, allowing
+// tests to assert on emitted metrics. It is safe for concurrent use.
+type Recorder struct {
+	ml      sync.Mutex
+	entries []metrics.Entry
+}
+
+// Handle implements the metrics.Processors interface.
+func (r *Recorder) Handle(en metrics.Entry) error {
+	r.ml.Lock()
+	defer r.ml.Unlock()
+
+	r.entries = append(r.entries, en)
+	return nil
+}
+
+// Entries returns a copy of all recorded entries.
+func (r *Recorder) Entries() []metrics.Entry {
+	r.ml.Lock()
+	defer r.ml.Unlock()
+
+	entries := make([]metrics.Entry, len(r.entries))
+	copy(entries, r.entries)
+	return entries
+}
+
+// Find returns all recorded entries with the giving id.
+func (r *Recorder) Find(id string) []metrics.Entry {
+	r.ml.Lock()
+	defer r.ml.Unlock()
+
+	var found []metrics.Entry
+	for _, en := range r.entries {
+		if en.ID == id {
+			found = append(found, en)
+		}
+	}
+	return found
+}
+
+// Reset drops all recorded entries.
+func (r *Recorder) Reset() {
+	r.ml.Lock()
+	defer r.ml.Unlock()
+	r.entries = nil
+}
@@ -0,0 +1,84 @@
+package tester_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/metrics"
+	"github.com/influx6/faux/tester"
+)
+
+// fakeTB records failures instead of stopping the test.
+type fakeTB struct {
+	testing.TB
+	failures []string
+}
+
+func (f *fakeTB) Helper()                                 {}
+func (f *fakeTB) Logf(format string, args ...interface{}) {}
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+type user struct {
+	Name string
+	Age  int
+}
+
+func TestAssertions(t *testing.T) {
+	tt := tester.New(t)
+
+	tt.Equal(user{Name: "bob", Age: 20}, user{Name: "bob", Age: 20}, "Should match equal users")
+	tt.NotEqual(1, 2, "Should not match different numbers")
+	tt.NoError(nil, "Should have no error")
+	tt.ExpectError(errors.New("bad"), "Should have error")
+	tt.PanicsWith("boom", func() { panic("boom") }, "Should panic with boom")
+	tt.Check(true, "Should pass check")
+
+	var ready int32
+	time.AfterFunc(20*time.Millisecond, func() { atomic.StoreInt32(&ready, 1) })
+	tt.Eventually(func() bool { return atomic.LoadInt32(&ready) == 1 }, time.Second, "Should eventually be ready")
+}
+
+func TestFailures(t *testing.T) {
+	fake := &fakeTB{TB: t}
+	tt := tester.New(fake)
+
+	tt.Equal(user{Name: "bob", Age: 20}, user{Name: "bob", Age: 21}, "users")
+	tt.NoError(errors.New("bad"), "no error")
+	tt.PanicsWith(nil, func() {}, "panics")
+	tt.PanicsWith("boom", func() { panic("bang") }, "panics with")
+	tt.Eventually(func() bool { return false }, 20*time.Millisecond, "eventually")
+
+	if len(fake.failures) != 5 {
+		t.Fatalf("Should have recorded all failures: %+v", fake.failures)
+	}
+
+	if !strings.Contains(fake.failures[0], ".Age: 20 != 21") {
+		t.Fatalf("Should have shown diff of users: %q", fake.failures[0])
+	}
+
+	if !strings.Contains(fake.failures[2], "did not panic") {
+		t.Fatalf("Should have reported missing panic: %q", fake.failures[2])
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	tt := tester.New(t)
+
+	var recorder tester.Recorder
+	m := metrics.New(&recorder)
+
+	m.Emit(metrics.Info("started"), metrics.WithID("app:start"))
+	m.Emit(metrics.Info("stopped"), metrics.WithID("app:stop"))
+
+	tt.Equal(2, len(recorder.Entries()), "Should have recorded all entries")
+	tt.Equal("stopped", recorder.Find("app:stop")[0].Message, "Should have found entry by id")
+
+	recorder.Reset()
+	tt.Equal(0, len(recorder.Entries()), "Should have dropped all entries")
+}
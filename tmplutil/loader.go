@@ -0,0 +1,307 @@
+package tmplutil
+
+import (
+	"bytes"
+	"errors"
+	htemplate "html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// errors.
+var (
+	ErrNoFileSystem     = errors.New("Config.FS is required")
+	ErrTemplateNotFound = errors.New("template not found")
+)
+
+// DefaultFuncs returns a copy of the default template functions which are
+// added to all templates created by this package, usable with both
+// text/template and html/template.
+func DefaultFuncs() template.FuncMap {
+	funcs := make(template.FuncMap, len(defaultFuncs))
+	for name, fn := range defaultFuncs {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+// executor defines the common execution method of text and html templates.
+type executor interface {
+	ExecuteTemplate(io.Writer, string, interface{}) error
+}
+
+// Config defines the configuration for a Loader.
+type Config struct {
+	// FS sets the filesystem templates are loaded from, i.e os.DirFS(dir) or
+	// an embed.FS.
+	FS fs.FS
+
+	// Extension sets the file extension of templates, defaulting to ".tml".
+	Extension string
+
+	// Layouts sets the directory of layout templates within FS.
+	Layouts string
+
+	// Partials sets the directory of partial templates within FS, which are
+	// added to every compiled template.
+	Partials string
+
+	// DefaultLayout sets the layout used by Render, where an empty value
+	// renders pages without a layout.
+	DefaultLayout string
+
+	// HTML switches compilation to html/template for contextual escaping.
+	HTML bool
+
+	// Reload recompiles templates when their files change, meant for
+	// development only.
+	Reload bool
+
+	// Funcs adds extra functions to all templates.
+	Funcs template.FuncMap
+}
+
+// compiled holds a compiled template with the modification times of the
+// files it was compiled from.
+type compiled struct {
+	exec  executor
+	entry string
+	files map[string]time.Time
+}
+
+// Loader compiles and caches templates loaded from a filesystem, composing
+// pages with layouts and partials. A page is executed within a layout by
+// having the layout call {{template "content" .}}, where the page declares
+// {{define "content"}}...{{end}}.
+type Loader struct {
+	config Config
+	ml     sync.RWMutex
+	cache  map[string]*compiled
+}
+
+// NewLoader returns a new instance of a Loader.
+func NewLoader(config Config) (*Loader, error) {
+	if config.FS == nil {
+		return nil, ErrNoFileSystem
+	}
+
+	if config.Extension == "" {
+		config.Extension = ".tml"
+	}
+
+	return &Loader{
+		config: config,
+		cache:  make(map[string]*compiled),
+	}, nil
+}
+
+// Render executes the giving page with the default layout into w. It allows
+// a Loader to be used as a httputil.Render.
+func (l *Loader) Render(w io.Writer, page string, data interface{}) error {
+	return l.RenderLayout(w, l.config.DefaultLayout, page, data)
+}
+
+// RenderLayout executes the giving page within the provided layout into w.
+// An empty layout executes the page on it's own.
+func (l *Loader) RenderLayout(w io.Writer, layout string, page string, data interface{}) error {
+	tml, err := l.lookup(layout, page)
+	if err != nil {
+		return err
+	}
+
+	return tml.exec.ExecuteTemplate(w, tml.entry, data)
+}
+
+// Handler returns a http.Handler which renders the giving page with the
+// default layout, using the data returned by fn for the request. It responds
+// with a 404 status if the page or it's layout does not exist.
+func (l *Loader) Handler(page string, fn func(*http.Request) (interface{}, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data interface{}
+		if fn != nil {
+			var err error
+			if data, err = fn(r); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		var bu bytes.Buffer
+		if err := l.Render(&bu, page, data); err != nil {
+			if errors.Is(err, ErrTemplateNotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if l.config.HTML {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		} else {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		bu.WriteTo(w)
+	})
+}
+
+// lookup returns the compiled template for the giving layout and page,
+// compiling it if not yet cached or if it's files changed in reload mode.
+func (l *Loader) lookup(layout string, page string) (*compiled, error) {
+	key := layout + "|" + page
+
+	l.ml.RLock()
+	tml, ok := l.cache[key]
+	l.ml.RUnlock()
+
+	if ok && (!l.config.Reload || !l.changed(tml)) {
+		return tml, nil
+	}
+
+	tml, err := l.compile(layout, page)
+	if err != nil {
+		return nil, err
+	}
+
+	l.ml.Lock()
+	l.cache[key] = tml
+	l.ml.Unlock()
+
+	return tml, nil
+}
+
+// Reset clears all compiled templates from the cache.
+func (l *Loader) Reset() {
+	l.ml.Lock()
+	l.cache = make(map[string]*compiled)
+	l.ml.Unlock()
+}
+
+// changed returns true if any file of the compiled template was modified or
+// partials were added or removed.
+func (l *Loader) changed(tml *compiled) bool {
+	for file, mod := range tml.files {
+		info, err := fs.Stat(l.config.FS, file)
+		if err != nil || !info.ModTime().Equal(mod) {
+			return true
+		}
+	}
+
+	partials, err := l.partials()
+	if err != nil {
+		return true
+	}
+
+	for _, partial := range partials {
+		if _, ok := tml.files[partial]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// partials returns the paths of all partial templates.
+func (l *Loader) partials() ([]string, error) {
+	if l.config.Partials == "" {
+		return nil, nil
+	}
+
+	files, err := fs.Glob(l.config.FS, path.Join(l.config.Partials, "*"+l.config.Extension))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// resolve returns the path of the giving template name within a directory,
+// adding the extension if missing.
+func (l *Loader) resolve(dir string, name string) string {
+	if !strings.HasSuffix(name, l.config.Extension) {
+		name += l.config.Extension
+	}
+
+	return path.Join(dir, name)
+}
+
+// compile parses the layout, page and all partials into a single template.
+func (l *Loader) compile(layout string, page string) (*compiled, error) {
+	files, err := l.partials()
+	if err != nil {
+		return nil, err
+	}
+
+	pageFile := l.resolve("", page)
+	entry := pageFile
+
+	if layout != "" {
+		layoutFile := l.resolve(l.config.Layouts, layout)
+		files = append(files, layoutFile)
+		entry = layoutFile
+	}
+
+	files = append(files, pageFile)
+
+	tml := compiled{
+		entry: entry,
+		files: make(map[string]time.Time, len(files)),
+	}
+
+	// the root templates are unnamed, as html/template loses the definitions
+	// of a file parsed under the root's own name.
+	var ttml *template.Template
+	var html *htemplate.Template
+
+	for _, file := range files {
+		data, err := fs.ReadFile(l.config.FS, file)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil, ErrTemplateNotFound
+			}
+			return nil, err
+		}
+
+		if info, err := fs.Stat(l.config.FS, file); err == nil {
+			tml.files[file] = info.ModTime()
+		}
+
+		if l.config.HTML {
+			if html == nil {
+				html = htemplate.New("").Funcs(htemplate.FuncMap(defaultFuncs)).Funcs(htemplate.FuncMap(l.config.Funcs))
+			}
+
+			if _, err := html.New(file).Parse(string(data)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if ttml == nil {
+			ttml = template.New("").Funcs(defaultFuncs).Funcs(l.config.Funcs)
+		}
+
+		if _, err := ttml.New(file).Parse(string(data)); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.config.HTML {
+		tml.exec = html
+	} else {
+		tml.exec = ttml
+	}
+
+	return &tml, nil
+}
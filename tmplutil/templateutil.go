@@ -1,6 +1,7 @@
 package tmplutil
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path"
@@ -8,6 +9,7 @@ import (
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 )
 
 var (
@@ -173,6 +175,20 @@ var (
 		"doPrefixCut":   cutListPrefix,
 		"doSuffixCut":   cutListSuffix,
 		"intsToString":  doIntToString,
+		"toJSON":        toJSON,
+		"dict":          dict,
+		"default": func(def interface{}, b interface{}) interface{} {
+			if b == nil {
+				return def
+			}
+			if str, ok := b.(string); ok && str == "" {
+				return def
+			}
+			return b
+		},
+		"formatTime": func(layout string, t time.Time) string {
+			return t.Format(layout)
+		},
 	}
 )
 
@@ -567,3 +583,28 @@ func lenOff(b interface{}) int {
 		return 0
 	}
 }
+
+func toJSON(b interface{}) (string, error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New("dict requires key-value pairs")
+	}
+
+	items := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict key %+v must be a string", pairs[i])
+		}
+		items[key] = pairs[i+1]
+	}
+
+	return items, nil
+}
@@ -2,7 +2,11 @@ package tmplutil_test
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/influx6/faux/tests"
 	"github.com/influx6/faux/tmplutil"
@@ -73,3 +77,115 @@ func TestGroupNew(t *testing.T) {
 	}
 	tests.Passed("Should have succesffuly matched expected response.")
 }
+
+func TestLoader(t *testing.T) {
+	files := fstest.MapFS{
+		"layouts/base.tml":  &fstest.MapFile{Data: []byte(`<main>{{template "content" .}}</main>`)},
+		"partials/name.tml": &fstest.MapFile{Data: []byte(`{{define "name"}}{{.Name | upper}}{{end}}`)},
+		"index.tml":         &fstest.MapFile{Data: []byte(`{{define "content"}}Hello {{template "name" .}}{{end}}`)},
+		"plain.tml":         &fstest.MapFile{Data: []byte(`Hi {{default "guest" .Name}}`)},
+	}
+
+	loader, err := tmplutil.NewLoader(tmplutil.Config{
+		FS:            files,
+		Layouts:       "layouts",
+		Partials:      "partials",
+		DefaultLayout: "base",
+		Reload:        true,
+	})
+	if err != nil {
+		tests.Failed("Should have successfully created loader: %+q", err)
+	}
+	tests.Passed("Should have successfully created loader.")
+
+	var buf bytes.Buffer
+	if err := loader.Render(&buf, "index", map[string]string{"Name": "rico"}); err != nil {
+		tests.Failed("Should have successfully rendered page: %+q", err)
+	}
+	tests.Passed("Should have successfully rendered page.")
+
+	if buf.String() != "<main>Hello RICO</main>" {
+		tests.Failed("Should have matched rendered layout but got %+q", buf.String())
+	}
+	tests.Passed("Should have matched rendered layout.")
+
+	files["index.tml"] = &fstest.MapFile{Data: []byte(`{{define "content"}}Bye {{template "name" .}}{{end}}`), ModTime: time.Now()}
+
+	buf.Reset()
+	if err := loader.Render(&buf, "index", map[string]string{"Name": "rico"}); err != nil {
+		tests.Failed("Should have successfully rendered page: %+q", err)
+	}
+
+	if buf.String() != "<main>Bye RICO</main>" {
+		tests.Failed("Should have reloaded changed page but got %+q", buf.String())
+	}
+	tests.Passed("Should have reloaded changed page.")
+
+	buf.Reset()
+	if err := loader.RenderLayout(&buf, "", "plain", map[string]string{}); err != nil {
+		tests.Failed("Should have successfully rendered page without layout: %+q", err)
+	}
+
+	if buf.String() != "Hi guest" {
+		tests.Failed("Should have matched rendered page but got %+q", buf.String())
+	}
+	tests.Passed("Should have rendered page without layout.")
+
+	if err := loader.Render(&buf, "missing", nil); err != tmplutil.ErrTemplateNotFound {
+		tests.Failed("Should have failed to find missing page: %+q", err)
+	}
+	tests.Passed("Should have failed to find missing page.")
+}
+
+func TestLoaderHTML(t *testing.T) {
+	files := fstest.MapFS{
+		"layouts/base.tml":  &fstest.MapFile{Data: []byte(`<main title="{{.Title}}">{{template "content" .}}</main>`)},
+		"partials/name.tml": &fstest.MapFile{Data: []byte(`{{define "name"}}<b>{{.Name}}</b>{{end}}`)},
+		"index.tml":         &fstest.MapFile{Data: []byte(`{{define "content"}}Hello {{template "name" .}}{{end}}`)},
+	}
+
+	loader, err := tmplutil.NewLoader(tmplutil.Config{
+		FS:            files,
+		Layouts:       "layouts",
+		Partials:      "partials",
+		DefaultLayout: "base",
+		HTML:          true,
+	})
+	if err != nil {
+		tests.Failed("Should have successfully created loader: %+q", err)
+	}
+	tests.Passed("Should have successfully created loader.")
+
+	data := map[string]string{"Name": "<script>alert(1)</script>", "Title": `"quoted"`}
+
+	var buf bytes.Buffer
+	if err := loader.Render(&buf, "index", data); err != nil {
+		tests.Failed("Should have successfully rendered page: %+q", err)
+	}
+
+	expected := `<main title="&#34;quoted&#34;">Hello <b>&lt;script&gt;alert(1)&lt;/script&gt;</b></main>`
+	if buf.String() != expected {
+		tests.Failed("Should have escaped html output but got %+q", buf.String())
+	}
+	tests.Passed("Should have escaped html output.")
+
+	handler := loader.Handler("index", func(*http.Request) (interface{}, error) {
+		return data, nil
+	})
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest("GET", "/", nil))
+
+	if res.Code != http.StatusOK || res.Header().Get("Content-Type") != "text/html; charset=utf-8" || res.Body.String() != expected {
+		tests.Failed("Should have served escaped html page: %d %+q", res.Code, res.Body.String())
+	}
+	tests.Passed("Should have served escaped html page.")
+
+	res = httptest.NewRecorder()
+	loader.Handler("missing", nil).ServeHTTP(res, httptest.NewRequest("GET", "/missing", nil))
+
+	if res.Code != http.StatusNotFound {
+		tests.Failed("Should have responded with not found for missing page: %d", res.Code)
+	}
+	tests.Passed("Should have responded with not found for missing page.")
+}
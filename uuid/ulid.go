@@ -0,0 +1,184 @@
+package uuid
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// crockford defines the Crockford base32 alphabet used to encode ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID defines a 128 bit identifier made of a 48 bit millisecond timestamp
+// followed by 80 random bits, whose string form sorts in creation order.
+type ULID [16]byte
+
+// ulids holds the state of the monotonic ULID generator.
+var ulids = struct {
+	ml     sync.Mutex
+	lastMS uint64
+	last   ULID
+}{}
+
+// NewULID returns a new ULID for the current time. ULIDs created within the
+// same millisecond, or after the clock moved backwards, increment the random
+// part of the previous one, so they remain strictly ordered. It panics if the
+// random source fails.
+func NewULID() ULID {
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+
+	ulids.ml.Lock()
+	defer ulids.ml.Unlock()
+
+	if ms <= ulids.lastMS {
+		ms = ulids.lastMS
+
+		u := ulids.last
+		if increment(u[6:]) {
+			ms++
+			u.setTime(ms)
+		}
+
+		ulids.lastMS, ulids.last = ms, u
+		return u
+	}
+
+	u, err := NewULIDFrom(time.Unix(0, int64(ms)*int64(time.Millisecond)), defaultPool)
+	if err != nil {
+		panic(err)
+	}
+
+	ulids.lastMS, ulids.last = ms, u
+	return u
+}
+
+// NewULIDFrom returns a new ULID for the giving time using random bytes read
+// from r. It always carries the giving time and does not take part in the
+// monotonic ordering of NewULID.
+func NewULIDFrom(t time.Time, r io.Reader) (ULID, error) {
+	var u ULID
+	if _, err := io.ReadFull(r, u[6:]); err != nil {
+		return ULID{}, err
+	}
+
+	u.setTime(uint64(t.UnixNano() / int64(time.Millisecond)))
+	return u, nil
+}
+
+// ParseULID returns the ULID of the giving 26 character string form.
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if err := u.UnmarshalText([]byte(s)); err != nil {
+		return ULID{}, err
+	}
+	return u, nil
+}
+
+// Time returns the timestamp of the ULID.
+func (u ULID) Time() time.Time {
+	var ms uint64
+	for _, b := range u[:6] {
+		ms = ms<<8 | uint64(b)
+	}
+	return time.Unix(0, int64(ms)*int64(time.Millisecond))
+}
+
+// Bytes returns the bytes of the ULID.
+func (u ULID) Bytes() []byte {
+	return u[:]
+}
+
+// String returns the Crockford base32 form of the ULID.
+func (u ULID) String() string {
+	var buf [26]byte
+	u.encode(buf[:])
+	return string(buf[:])
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u ULID) MarshalText() ([]byte, error) {
+	buf := make([]byte, 26)
+	u.encode(buf)
+	return buf, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, accepting
+// both upper and lower case.
+func (u *ULID) UnmarshalText(text []byte) error {
+	if len(text) != 26 {
+		return ErrInvalidULID
+	}
+
+	var out ULID
+	for i, c := range text {
+		v := decodeCrockford(c)
+		if v < 0 || (i == 0 && v > 7) {
+			return ErrInvalidULID
+		}
+
+		// the string carries 130 bits, the first two of which are padding.
+		for b := 0; b < 5; b++ {
+			pos := i*5 + b - 2
+			if pos < 0 || (v>>(4-uint(b)))&1 == 0 {
+				continue
+			}
+			out[pos/8] |= 1 << (7 - uint(pos%8))
+		}
+	}
+
+	*u = out
+	return nil
+}
+
+func (u ULID) encode(buf []byte) {
+	for i := range buf {
+		var v byte
+		for b := 0; b < 5; b++ {
+			v <<= 1
+
+			pos := i*5 + b - 2
+			if pos >= 0 {
+				v |= (u[pos/8] >> (7 - uint(pos%8))) & 1
+			}
+		}
+		buf[i] = crockford[v]
+	}
+}
+
+func (u *ULID) setTime(ms uint64) {
+	for i := 5; i >= 0; i-- {
+		u[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+// increment adds one to the big-endian bytes, returning true on overflow.
+func increment(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeCrockford(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+
+	switch c {
+	case 'O':
+		return 0
+	case 'I', 'L':
+		return 1
+	}
+
+	for index := 0; index < len(crockford); index++ {
+		if crockford[index] == c {
+			return index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,163 @@
+// Package uuid provides fast generation of random version 4 UUIDs and
+// lexically sortable ULID-style identifiers. Random bytes are read from
+// crypto/rand in large batches into a pool, so generating an id does not
+// allocate or issue a system call per id.
+package uuid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sync"
+)
+
+// errors.
+var (
+	ErrInvalidUUID = errors.New("invalid uuid string")
+	ErrInvalidULID = errors.New("invalid ulid string")
+)
+
+// poolSize sets the total bytes read from the random source at a time.
+const poolSize = 16 * 256
+
+// Pool defines a pre-allocated buffer of random bytes refilled from a
+// random source when exhausted. It is safe for concurrent use.
+type Pool struct {
+	ml     sync.Mutex
+	source io.Reader
+	buf    [poolSize]byte
+	pos    int
+}
+
+// NewPool returns a new instance of a Pool reading from the giving source.
+func NewPool(source io.Reader) *Pool {
+	return &Pool{source: source, pos: poolSize}
+}
+
+// Read fills b with random bytes from the pool.
+func (p *Pool) Read(b []byte) (int, error) {
+	p.ml.Lock()
+	defer p.ml.Unlock()
+
+	var total int
+	for total < len(b) {
+		if p.pos == poolSize {
+			if _, err := io.ReadFull(p.source, p.buf[:]); err != nil {
+				return total, err
+			}
+			p.pos = 0
+		}
+
+		n := copy(b[total:], p.buf[p.pos:])
+		p.pos += n
+		total += n
+	}
+
+	return total, nil
+}
+
+// defaultPool is used by all package level generators.
+var defaultPool = NewPool(rand.Reader)
+
+//==============================================================================
+
+// UUID defines a RFC 4122 UUID.
+type UUID [16]byte
+
+// Nil defines the UUID with all bits set to zero.
+var Nil UUID
+
+// NewV4 returns a new random version 4 UUID. It panics if the random source
+// fails, which crypto/rand does not do in practice.
+func NewV4() UUID {
+	var u UUID
+	if _, err := defaultPool.Read(u[:]); err != nil {
+		panic(err)
+	}
+
+	u.setVersion()
+	return u
+}
+
+// NewV4From returns a new random version 4 UUID using bytes read from r.
+func NewV4From(r io.Reader) (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(r, u[:]); err != nil {
+		return Nil, err
+	}
+
+	u.setVersion()
+	return u, nil
+}
+
+// Parse returns the UUID of the giving canonical string form,
+// i.e "6ba7b810-9dad-11d1-80b4-00c04fd430c8".
+func Parse(s string) (UUID, error) {
+	var u UUID
+	if err := u.UnmarshalText([]byte(s)); err != nil {
+		return Nil, err
+	}
+	return u, nil
+}
+
+// Version returns the version of the UUID.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Bytes returns the bytes of the UUID.
+func (u UUID) Bytes() []byte {
+	return u[:]
+}
+
+// String returns the canonical string form of the UUID.
+func (u UUID) String() string {
+	var buf [36]byte
+	u.encode(buf[:])
+	return string(buf[:])
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u UUID) MarshalText() ([]byte, error) {
+	buf := make([]byte, 36)
+	u.encode(buf)
+	return buf, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *UUID) UnmarshalText(text []byte) error {
+	if len(text) != 36 || text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+		return ErrInvalidUUID
+	}
+
+	var src [32]byte
+	copy(src[0:8], text[0:8])
+	copy(src[8:12], text[9:13])
+	copy(src[12:16], text[14:18])
+	copy(src[16:20], text[19:23])
+	copy(src[20:32], text[24:36])
+
+	if _, err := hex.Decode(u[:], src[:]); err != nil {
+		return ErrInvalidUUID
+	}
+
+	return nil
+}
+
+func (u *UUID) setVersion() {
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+}
+
+func (u UUID) encode(buf []byte) {
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+}
@@ -0,0 +1,147 @@
+package uuid_test
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/influx6/faux/uuid"
+)
+
+func TestUUID(t *testing.T) {
+	seen := make(map[uuid.UUID]bool)
+	for i := 0; i < 1000; i++ {
+		u := uuid.NewV4()
+		if seen[u] {
+			t.Fatalf("Should have generated unique uuids: %s", u)
+		}
+		seen[u] = true
+
+		if u.Version() != 4 || u[8]&0xc0 != 0x80 {
+			t.Fatalf("Should have generated version 4 variant 1 uuid: %s", u)
+		}
+	}
+
+	u := uuid.NewV4()
+	parsed, err := uuid.Parse(u.String())
+	if err != nil || parsed != u {
+		t.Fatalf("Should have parsed uuid string %q: %+v", u, err)
+	}
+
+	if _, err := uuid.Parse("6ba7b810-9dad-11d1-80b4"); err != uuid.ErrInvalidUUID {
+		t.Fatalf("Should have rejected short uuid: %+v", err)
+	}
+
+	known, err := uuid.Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	if err != nil || known.String() != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Fatalf("Should have round-tripped known uuid: %s %+v", known, err)
+	}
+}
+
+func TestPool(t *testing.T) {
+	source := bytes.NewReader(bytes.Repeat([]byte{1, 2, 3, 4}, 16*256*2))
+	pool := uuid.NewPool(source)
+
+	u, err := uuid.NewV4From(pool)
+	if err != nil {
+		t.Fatalf("Should have read uuid from pool: %+v", err)
+	}
+
+	if u[0] != 1 || u[1] != 2 || u.Version() != 4 {
+		t.Fatalf("Should have used pool bytes: %s", u)
+	}
+
+	buf := make([]byte, 16*256)
+	if _, err := pool.Read(buf); err != nil {
+		t.Fatalf("Should have refilled pool: %+v", err)
+	}
+}
+
+func TestULID(t *testing.T) {
+	now := time.Now()
+
+	ids := make([]string, 1000)
+	for i := range ids {
+		ids[i] = uuid.NewULID().String()
+	}
+
+	if !sort.StringsAreSorted(ids) {
+		t.Fatalf("Should have generated strictly ordered ulids")
+	}
+
+	for i := 1; i < len(ids); i++ {
+		if ids[i] == ids[i-1] {
+			t.Fatalf("Should have generated unique ulids: %s", ids[i])
+		}
+	}
+
+	u := uuid.NewULID()
+	parsed, err := uuid.ParseULID(u.String())
+	if err != nil || parsed != u {
+		t.Fatalf("Should have parsed ulid string %q: %+v", u, err)
+	}
+
+	if diff := u.Time().Sub(now); diff < -time.Millisecond || diff > time.Second {
+		t.Fatalf("Should have encoded current time: %s", u.Time())
+	}
+
+	known := "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	k, err := uuid.ParseULID(known)
+	if err != nil || k.String() != known {
+		t.Fatalf("Should have round-tripped known ulid: %s %+v", k, err)
+	}
+
+	if k.Time().UnixNano()/int64(time.Millisecond) != 1469922850259 {
+		t.Fatalf("Should have decoded known ulid time: %d", k.Time().UnixNano()/int64(time.Millisecond))
+	}
+
+	if _, err := uuid.ParseULID("81ARZ3NDEKTSV4RRFFQ69G5FAV"); err != uuid.ErrInvalidULID {
+		t.Fatalf("Should have rejected overflowing ulid: %+v", err)
+	}
+}
+
+func TestULIDFrom(t *testing.T) {
+	uuid.NewULID()
+
+	past := time.Unix(0, 1469922850259*int64(time.Millisecond))
+	random := bytes.Repeat([]byte{0xff}, 10)
+
+	u, err := uuid.NewULIDFrom(past, bytes.NewReader(random))
+	if err != nil {
+		t.Fatalf("Should have created ulid: %+v", err)
+	}
+
+	if !u.Time().Equal(past) {
+		t.Fatalf("Should have kept giving time despite later ulids: %s", u.Time())
+	}
+
+	if !bytes.Equal(u.Bytes()[6:], random) {
+		t.Fatalf("Should have used random bytes from reader: %x", u.Bytes())
+	}
+
+	if _, err := uuid.NewULIDFrom(past, bytes.NewReader(nil)); err == nil {
+		t.Fatalf("Should have failed with exhausted reader")
+	}
+}
+
+func BenchmarkNewV4(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		uuid.NewV4()
+	}
+}
+
+func BenchmarkNewV4String(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = uuid.NewV4().String()
+	}
+}
+
+func BenchmarkNewULID(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		uuid.NewULID()
+	}
+}
@@ -0,0 +1,186 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Rule defines a function type which validates a giving value, returning an
+// error describing why the value is invalid.
+type Rule func(value interface{}) error
+
+// Required returns a Rule which fails if the value is the zero value of it's
+// type, a nil pointer or an empty string, slice or map.
+func Required() Rule {
+	return func(value interface{}) error {
+		val := reflect.ValueOf(value)
+		if !val.IsValid() {
+			return FieldError{Rule: "required", Message: "is required"}
+		}
+
+		switch val.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+			if val.Len() == 0 {
+				return FieldError{Rule: "required", Message: "is required", Value: value}
+			}
+			return nil
+		case reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
+			if val.IsNil() {
+				return FieldError{Rule: "required", Message: "is required", Value: value}
+			}
+			return nil
+		}
+
+		if reflect.DeepEqual(value, reflect.Zero(val.Type()).Interface()) {
+			return FieldError{Rule: "required", Message: "is required", Value: value}
+		}
+
+		return nil
+	}
+}
+
+// Min returns a Rule which fails if a numeric value is less than min, or the
+// length of a string, slice or map is less than min.
+func Min(min float64) Rule {
+	return func(value interface{}) error {
+		num, isLen, ok := measure(value)
+		if !ok {
+			return nil
+		}
+
+		if num < min {
+			if isLen {
+				return FieldError{Rule: "min", Message: fmt.Sprintf("must have a length of at least %v", min), Value: value}
+			}
+			return FieldError{Rule: "min", Message: fmt.Sprintf("must be at least %v", min), Value: value}
+		}
+
+		return nil
+	}
+}
+
+// Max returns a Rule which fails if a numeric value is greater than max, or
+// the length of a string, slice or map is greater than max.
+func Max(max float64) Rule {
+	return func(value interface{}) error {
+		num, isLen, ok := measure(value)
+		if !ok {
+			return nil
+		}
+
+		if num > max {
+			if isLen {
+				return FieldError{Rule: "max", Message: fmt.Sprintf("must have a length of at most %v", max), Value: value}
+			}
+			return FieldError{Rule: "max", Message: fmt.Sprintf("must be at most %v", max), Value: value}
+		}
+
+		return nil
+	}
+}
+
+// Pattern returns a Rule which fails if the string value does not match the
+// giving regular expression. It panics if the expression is invalid.
+func Pattern(expr string) Rule {
+	return MatchRegexp(regexp.MustCompile(expr))
+}
+
+// MatchRegexp returns a Rule which fails if the string value does not match
+// the giving regular expression.
+func MatchRegexp(rx *regexp.Regexp) Rule {
+	return func(value interface{}) error {
+		val := reflect.ValueOf(value)
+		if val.Kind() != reflect.String {
+			return nil
+		}
+
+		if !rx.MatchString(val.String()) {
+			return FieldError{Rule: "pattern", Message: fmt.Sprintf("must match pattern %q", rx.String()), Value: value}
+		}
+
+		return nil
+	}
+}
+
+// OneOf returns a Rule which fails if the string form of the value is not one
+// of the provided options.
+func OneOf(options ...string) Rule {
+	return func(value interface{}) error {
+		current := fmt.Sprint(value)
+		for _, option := range options {
+			if option == current {
+				return nil
+			}
+		}
+
+		return FieldError{Rule: "oneof", Message: fmt.Sprintf("must be one of [%s]", strings.Join(options, ", ")), Value: value}
+	}
+}
+
+// Func returns a Rule which fails with the giving message if the provided
+// function returns false.
+func Func(name string, message string, fn func(interface{}) bool) Rule {
+	return func(value interface{}) error {
+		if !fn(value) {
+			return FieldError{Rule: name, Message: message, Value: value}
+		}
+		return nil
+	}
+}
+
+//==============================================================================
+
+func minGenerator(param string) (Rule, error) {
+	min, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid min parameter %q", param)
+	}
+
+	return Min(min), nil
+}
+
+func maxGenerator(param string) (Rule, error) {
+	max, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max parameter %q", param)
+	}
+
+	return Max(max), nil
+}
+
+func patternGenerator(param string) (Rule, error) {
+	rx, err := regexp.Compile(param)
+	if err != nil {
+		return nil, err
+	}
+
+	return MatchRegexp(rx), nil
+}
+
+// measure returns the numeric value or length of the giving value, with a
+// flag indicating if it's a length.
+func measure(value interface{}) (float64, bool, bool) {
+	val := reflect.ValueOf(value)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return 0, false, false
+		}
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return float64(val.Len()), true, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(val.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), false, true
+	}
+
+	return 0, false, false
+}
@@ -0,0 +1,331 @@
+// Package validate provides declarative validation of values through struct
+// tags and programmatic rules, returning structured field errors.
+//
+//	type User struct {
+//		Name  string `validate:"required,min=3,max=20"`
+//		Role  string `validate:"oneof=admin user guest"`
+//		Email string `validate:"required,pattern=^[^@]+@[^@]+$"`
+//	}
+//
+// Rules within a tag are separated by commas. The pattern rule consumes the
+// rest of the tag, so it must be declared last.
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TagName defines the struct tag read by Struct.
+const TagName = "validate"
+
+// errors.
+var (
+	ErrNotStruct   = errors.New("value is not a struct or pointer to struct")
+	ErrUnknownRule = errors.New("unknown validation rule")
+)
+
+// FieldError defines a failed validation rule for a giving field.
+type FieldError struct {
+	Field   string      `json:"field"`
+	Rule    string      `json:"rule"`
+	Message string      `json:"message"`
+	Value   interface{} `json:"-"`
+}
+
+// Error implements the error interface.
+func (f FieldError) Error() string {
+	if f.Field == "" {
+		return f.Message
+	}
+
+	return fmt.Sprintf("%s: %s", f.Field, f.Message)
+}
+
+// Errors defines a list of FieldErrors returned from a validation.
+type Errors []FieldError
+
+// Error implements the error interface.
+func (e Errors) Error() string {
+	var bu bytes.Buffer
+	for index, fe := range e {
+		if index > 0 {
+			bu.WriteString("; ")
+		}
+
+		bu.WriteString(fe.Error())
+	}
+
+	return bu.String()
+}
+
+// Fields returns a map of field names to the messages of their failed rules.
+func (e Errors) Fields() map[string][]string {
+	fields := make(map[string][]string)
+	for _, fe := range e {
+		fields[fe.Field] = append(fields[fe.Field], fe.Message)
+	}
+
+	return fields
+}
+
+// Validator defines an interface for types which validate themselves. It is
+// called by Struct after all tag rules of the type have passed.
+type Validator interface {
+	Validate() error
+}
+
+//==============================================================================
+
+// Check validates the giving value against all provided rules, returning
+// an Errors with a FieldError for each failed rule, or nil.
+func Check(field string, value interface{}, rules ...Rule) error {
+	var errs Errors
+	for _, rule := range rules {
+		if err := rule(value); err != nil {
+			errs = append(errs, toFieldError(field, value, err))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// Join merges all provided errors into a single Errors, skipping nil values.
+// It returns nil if no error is provided.
+func Join(errs ...error) error {
+	var joined Errors
+	for _, err := range errs {
+		switch item := err.(type) {
+		case nil:
+		case Errors:
+			joined = append(joined, item...)
+		case FieldError:
+			joined = append(joined, item)
+		default:
+			joined = append(joined, FieldError{Message: err.Error()})
+		}
+	}
+
+	if len(joined) == 0 {
+		return nil
+	}
+
+	return joined
+}
+
+// Struct validates the giving struct or pointer to a struct using the rules
+// declared through the `validate` tag of it's fields. Nested structs are
+// validated with their field names prefixed by the parent field.
+func Struct(target interface{}) error {
+	val := reflect.ValueOf(target)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return ErrNotStruct
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+
+	var errs Errors
+	if err := validateStruct("", val, &errs, make(map[visit]bool)); err != nil {
+		return err
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// visit identifies a struct reached through a pointer.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// validateStruct runs all tag rules of the giving struct value, appending
+// failures into errs. It returns an error only for invalid tag declarations.
+// Structs already being validated higher up through visited are skipped, so
+// pointer cycles end.
+func validateStruct(prefix string, val reflect.Value, errs *Errors, visited map[visit]bool) error {
+	if val.CanAddr() {
+		key := visit{ptr: val.UnsafeAddr(), typ: val.Type()}
+		if visited[key] {
+			return nil
+		}
+
+		visited[key] = true
+		defer delete(visited, key)
+	}
+
+	tl := val.Type()
+	failed := len(*errs)
+
+	for i := 0; i < tl.NumField(); i++ {
+		field := tl.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		fval := val.Field(i)
+
+		tag := field.Tag.Get(TagName)
+		if tag == "-" {
+			continue
+		}
+
+		if tag != "" {
+			rules, err := parseTag(tag)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+
+			for _, rule := range rules {
+				if err := rule.fn(fval.Interface()); err != nil {
+					fe := toFieldError(name, fval.Interface(), err)
+					fe.Rule = rule.name
+					*errs = append(*errs, fe)
+				}
+			}
+		}
+
+		inner := fval
+		for inner.Kind() == reflect.Ptr && !inner.IsNil() {
+			inner = inner.Elem()
+		}
+
+		if inner.Kind() == reflect.Struct {
+			if err := validateStruct(name, inner, errs, visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	// skip the struct validator only if this struct or it's fields failed.
+	if len(*errs) != failed {
+		return nil
+	}
+
+	var vd Validator
+	if val.CanAddr() {
+		vd, _ = val.Addr().Interface().(Validator)
+	}
+
+	if vd == nil {
+		vd, _ = val.Interface().(Validator)
+	}
+
+	if vd != nil {
+		if err := vd.Validate(); err != nil {
+			*errs = append(*errs, toFieldError(prefix, nil, err))
+		}
+	}
+
+	return nil
+}
+
+// toFieldError converts the giving error into a FieldError for the field.
+func toFieldError(field string, value interface{}, err error) FieldError {
+	if fe, ok := err.(FieldError); ok {
+		if fe.Field == "" {
+			fe.Field = field
+		}
+		return fe
+	}
+
+	return FieldError{Field: field, Message: err.Error(), Value: value}
+}
+
+//==============================================================================
+
+// RuleGenerator defines a function which returns a Rule from the parameter
+// declared with a rule within a struct tag.
+type RuleGenerator func(param string) (Rule, error)
+
+var registry = struct {
+	ml    sync.RWMutex
+	rules map[string]RuleGenerator
+}{
+	rules: map[string]RuleGenerator{
+		"required": func(string) (Rule, error) { return Required(), nil },
+		"min":      minGenerator,
+		"max":      maxGenerator,
+		"pattern":  patternGenerator,
+		"oneof": func(param string) (Rule, error) {
+			return OneOf(strings.Fields(param)...), nil
+		},
+	},
+}
+
+// Register adds the giving RuleGenerator into the tag rules registry under
+// the provided name, replacing any existing rule of the same name.
+func Register(name string, gen RuleGenerator) {
+	registry.ml.Lock()
+	defer registry.ml.Unlock()
+	registry.rules[name] = gen
+}
+
+// namedRule pairs a Rule with the tag name it was declared with.
+type namedRule struct {
+	name string
+	fn   Rule
+}
+
+// parseTag parses a validation tag into it's list of rules.
+func parseTag(tag string) ([]namedRule, error) {
+	registry.ml.RLock()
+	defer registry.ml.RUnlock()
+
+	var rules []namedRule
+	for tag != "" {
+		var part string
+		if strings.HasPrefix(tag, "pattern=") {
+			part, tag = tag, ""
+		} else if index := strings.Index(tag, ","); index != -1 {
+			part, tag = tag[:index], tag[index+1:]
+		} else {
+			part, tag = tag, ""
+		}
+
+		name, param := part, ""
+		if index := strings.Index(part, "="); index != -1 {
+			name, param = part[:index], part[index+1:]
+		}
+
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		gen, ok := registry.rules[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownRule, name)
+		}
+
+		rule, err := gen(param)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, namedRule{name: name, fn: rule})
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,158 @@
+package validate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influx6/faux/validate"
+)
+
+type address struct {
+	City string `validate:"required"`
+	Zip  string `validate:"pattern=^[0-9]{5}$"`
+}
+
+type user struct {
+	Name    string   `validate:"required,min=3,max=10"`
+	Age     int      `validate:"min=18,max=120"`
+	Role    string   `validate:"oneof=admin user"`
+	Tags    []string `validate:"max=2"`
+	Address *address
+	secret  string
+}
+
+type account struct {
+	Password string `validate:"required"`
+	Confirm  string `validate:"required"`
+}
+
+func (a account) Validate() error {
+	if a.Password != a.Confirm {
+		return errors.New("passwords do not match")
+	}
+	return nil
+}
+
+func TestStruct(t *testing.T) {
+	valid := user{Name: "alex", Age: 20, Role: "admin", Address: &address{City: "Lagos", Zip: "12345"}}
+	if err := validate.Struct(valid); err != nil {
+		t.Fatalf("Should have passed validation: %+q", err)
+	}
+	t.Logf("Should have passed validation")
+
+	invalid := user{Name: "al", Age: 12, Role: "root", Tags: []string{"a", "b", "c"}, Address: &address{Zip: "abc"}}
+	err := validate.Struct(&invalid)
+	if err == nil {
+		t.Fatalf("Should have failed validation")
+	}
+
+	errs, ok := err.(validate.Errors)
+	if !ok {
+		t.Fatalf("Should have received validate.Errors: %T", err)
+	}
+
+	fields := errs.Fields()
+	for _, name := range []string{"Name", "Age", "Role", "Tags", "Address.City", "Address.Zip"} {
+		if _, ok := fields[name]; !ok {
+			t.Fatalf("Should have failed field %q: %+q", name, err)
+		}
+	}
+
+	if len(errs) != 6 {
+		t.Fatalf("Should have failed exactly six rules: %+q", err)
+	}
+
+	if errs[0].Rule != "min" {
+		t.Fatalf("Should have recorded failed rule name: %+v", errs[0])
+	}
+	t.Logf("Should have failed validation with field errors: %s", err)
+}
+
+func TestStructValidator(t *testing.T) {
+	if err := validate.Struct(account{Password: "a", Confirm: "a"}); err != nil {
+		t.Fatalf("Should have passed validation: %+q", err)
+	}
+
+	if err := validate.Struct(account{Password: "a", Confirm: "b"}); err == nil {
+		t.Fatalf("Should have failed custom validation")
+	}
+	t.Logf("Should have run custom validation")
+
+	var signup struct {
+		Name    string `validate:"required"`
+		Account account
+	}
+
+	signup.Account = account{Password: "a", Confirm: "b"}
+	err := validate.Struct(signup)
+
+	errs, ok := err.(validate.Errors)
+	if !ok {
+		t.Fatalf("Should have received validate.Errors: %T", err)
+	}
+
+	fields := errs.Fields()
+	if _, ok := fields["Name"]; !ok {
+		t.Fatalf("Should have failed required name: %+q", err)
+	}
+
+	if _, ok := fields["Account"]; !ok {
+		t.Fatalf("Should have run nested validator despite earlier failure: %+q", err)
+	}
+	t.Logf("Should have run nested validator after sibling failure")
+}
+
+type node struct {
+	Name   string `validate:"required"`
+	Parent *node
+}
+
+func TestStructCycle(t *testing.T) {
+	root := &node{Name: "root"}
+	child := &node{Parent: root}
+	root.Parent = child
+
+	err := validate.Struct(child)
+
+	var errs validate.Errors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "Name" {
+		t.Fatalf("Should have validated cyclic struct once: %+v", err)
+	}
+	t.Logf("Should have validated cyclic struct once")
+}
+
+func TestUnknownRule(t *testing.T) {
+	var bad struct {
+		Name string `validate:"unknown"`
+	}
+
+	if err := validate.Struct(bad); !errors.Is(err, validate.ErrUnknownRule) {
+		t.Fatalf("Should have failed with unknown rule: %+v", err)
+	}
+
+	validate.Register("unknown", func(param string) (validate.Rule, error) {
+		return validate.Required(), nil
+	})
+
+	if err := validate.Struct(bad); err == nil {
+		t.Fatalf("Should have failed registered required rule")
+	}
+	t.Logf("Should have used registered rule")
+}
+
+func TestCheck(t *testing.T) {
+	if err := validate.Check("port", 8080, validate.Min(1), validate.Max(65535)); err != nil {
+		t.Fatalf("Should have passed validation: %+q", err)
+	}
+
+	err := validate.Join(
+		validate.Check("port", 0, validate.Required(), validate.Min(1)),
+		validate.Check("host", "", validate.Required()),
+		nil,
+	)
+
+	if errs, ok := err.(validate.Errors); !ok || len(errs) != 3 {
+		t.Fatalf("Should have joined three field errors: %+q", err)
+	}
+	t.Logf("Should have joined field errors: %s", err)
+}